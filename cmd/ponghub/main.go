@@ -1,13 +1,23 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
 
 	"github.com/wcy-dt/ponghub/internal/checker"
 	"github.com/wcy-dt/ponghub/internal/configure"
+	"github.com/wcy-dt/ponghub/internal/daemon"
 	"github.com/wcy-dt/ponghub/internal/logger"
 	"github.com/wcy-dt/ponghub/internal/notifier"
 	"github.com/wcy-dt/ponghub/internal/reporter"
+	checkerTypes "github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	configureTypes "github.com/wcy-dt/ponghub/internal/types/structures/configure"
 	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
 )
 
@@ -18,32 +28,68 @@ func main() {
 		log.Fatalln("Error loading config at", default_config.GetConfigPath(), ":", err)
 	}
 
+	// `ponghub serve` keeps running and checks every service on its own interval
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		serve(cfg)
+		return
+	}
+
 	// check services based on the configuration
 	checkResult := checker.CheckServices(cfg)
+	if err := processRound(cfg, checkResult, checkResult); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+// serve runs the daemon mode until SIGINT or SIGTERM is received
+func serve(cfg *configureTypes.Configure) {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
+	// the daemon may run outside of the CI workflow, which creates the data directory
+	if err := os.MkdirAll(filepath.Dir(default_config.GetLogPath()), 0755); err != nil {
+		log.Fatalln("Error creating data directory:", err)
+	}
+
+	log.Println("Starting PongHub in daemon mode")
+	scheduler := daemon.NewScheduler(cfg, func(checkedResult, latestResult []checkerTypes.Service) {
+		// keep running on errors, the next round may succeed
+		if err := processRound(cfg, checkedResult, latestResult); err != nil {
+			log.Println(err)
+		}
+	})
+	if err := scheduler.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		log.Fatalln("Error running daemon:", err)
+	}
+	log.Println("PongHub daemon shut down")
+}
+
+// processRound notifies, logs and reports the results of one check round.
+// checkResult holds the services checked in this round, latestResult the latest result of every service.
+func processRound(cfg *configureTypes.Configure, checkResult, latestResult []checkerTypes.Service) error {
 	// notify the result
-	notifier.WriteNotifications(checkResult, cfg.CertNotifyDays)
-	notifier.SendNotifications(checkResult, cfg.CertNotifyDays, cfg.Notifications)
+	notifier.WriteNotifications(latestResult, cfg, default_config.GetNotifyPath())
+	notifier.SendNotifications(checkResult, latestResult, cfg, default_config.GetNotifyStatePath(), default_config.GetChannelStatePath(), default_config.GetLogPath())
 
 	// get and write log results
-	logResult, err := logger.GetLog(checkResult, cfg.MaxLogDays, default_config.GetLogPath())
+	logResult, err := logger.UpdateLog(checkResult, latestResult, cfg.MaxLogDays, default_config.GetLogPath())
 	if err != nil {
-		log.Fatalln("Error outputting checkResult:", err)
+		return fmt.Errorf("error outputting checkResult: %w", err)
 	}
 	if err := logger.WriteLog(logResult, default_config.GetLogPath()); err != nil {
-		log.Fatalln("Error writing logs to", default_config.GetLogPath(), ":", err)
-	} else {
-		log.Println("Logs written to", default_config.GetLogPath())
+		return fmt.Errorf("error writing logs to %s: %w", default_config.GetLogPath(), err)
 	}
+	log.Println("Logs written to", default_config.GetLogPath())
 
-	// generate the report based on the checkResult
-	reportResult, err := reporter.GetReport(checkResult, default_config.GetLogPath(), cfg)
+	// generate the report based on the latest result of every service
+	reportResult, err := reporter.GetReport(latestResult, default_config.GetLogPath(), default_config.GetNotifyStatePath(), cfg)
 	if err != nil {
-		log.Fatalln("Error generating report data:", err)
+		return fmt.Errorf("error generating report data: %w", err)
 	}
 	if err := reporter.WriteReport(reportResult, default_config.GetReportPath(), cfg.DisplayNum); err != nil {
-		log.Fatalln("Error generating report:", err)
-	} else {
-		log.Println("Report generated at", default_config.GetReportPath())
+		return fmt.Errorf("error generating report: %w", err)
 	}
+	log.Println("Report generated at", default_config.GetReportPath())
+
+	return nil
 }
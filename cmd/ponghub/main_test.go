@@ -17,17 +17,22 @@ import (
 
 // TestMain_append tests the main functionality when appending to an existing log file.
 func TestMain_append(t *testing.T) {
-	runMainFunctionality(true)
+	runMainFunctionality(t, true)
 }
 
 // TestMain_new tests the main functionality when creating a new log file.
 func TestMain_new(t *testing.T) {
-	runMainFunctionality(false)
+	runMainFunctionality(t, false)
 }
 
 // runMainFunctionality runs the main functionality for testing purposes.
 // If copyExistingLog is true, it copies the existing log file to a temporary location.
-func runMainFunctionality(copyExistingLog bool) {
+// The notification state is kept in a temporary directory, so the runs do not depend on each other.
+func runMainFunctionality(t *testing.T, copyExistingLog bool) {
+	stateDir := t.TempDir()
+	notifyStatePath := filepath.Join(stateDir, "notify_state.json")
+	channelStatePath := filepath.Join(stateDir, "notify_channels.json")
+
 	// load the default configuration
 	cfg, err := configure.ReadConfigs(default_config.GetConfigPath())
 	if err != nil {
@@ -46,8 +51,8 @@ func runMainFunctionality(copyExistingLog bool) {
 	checkResult := checker.CheckServices(cfg)
 
 	// notify the result
-	notifier.WriteNotifications(checkResult, cfg.CertNotifyDays)
-	notifier.SendNotifications(checkResult, cfg.CertNotifyDays, cfg.Notifications)
+	notifier.WriteNotifications(checkResult, cfg, filepath.Join(stateDir, "notify.txt"))
+	notifier.SendNotifications(checkResult, checkResult, cfg, notifyStatePath, channelStatePath, tmpLogPath)
 
 	// get and write log results
 	logResult, err := logger.GetLog(checkResult, cfg.MaxLogDays, tmpLogPath)
@@ -61,7 +66,7 @@ func runMainFunctionality(copyExistingLog bool) {
 	}
 
 	// generate the report based on the checkResult
-	reportResult, err := reporter.GetReport(checkResult, tmpLogPath, cfg)
+	reportResult, err := reporter.GetReport(checkResult, tmpLogPath, notifyStatePath, cfg)
 	if err != nil {
 		log.Fatalln("Error generating report data:", err)
 	}
@@ -0,0 +1,186 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+)
+
+// checkDNSEndpoint resolves a DNS name and checks the records against the expected values
+func checkDNSEndpoint(cfg *configure.Endpoint, timeout int, maxRetryTimes int, serviceName string) checker.Endpoint {
+	var failureDetails []string
+	successNum := 0
+	attemptNum := 0
+
+	var responseBody string
+	maxResponseTime := time.Duration(0)
+
+	displayURL, highlightSegments := getDisplayURL(cfg)
+	resolver := newDNSResolver(cfg.Resolver)
+
+	var expectRegex *regexp.Regexp
+	if cfg.ParsedExpect != "" {
+		expectRegex = regexp.MustCompile(cfg.ParsedExpect)
+	}
+
+	startTime := time.Now()
+	for currentAttemptNum := range maxRetryTimes {
+		attemptNum++
+		// Only log request details during tests to avoid exposing secrets
+		logIfTest("[%s] DNS %s %s (attempt %d/%d)\n",
+			serviceName, cfg.RecordType, cfg.ParsedURL, currentAttemptNum+1, maxRetryTimes)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+		reqStartTime := time.Now()
+		records, err := lookupRecords(ctx, resolver, cfg.RecordType, cfg.ParsedURL)
+		responseTime := time.Since(reqStartTime)
+		cancel()
+		if err != nil {
+			failureDetails = append(failureDetails, fmt.Sprintf("Error: %s", err.Error()))
+			log.Printf("FAILED - Error: %s", err.Error())
+			continue
+		}
+
+		if err := matchRecords(cfg.RecordType, records, cfg.ExpectValues, expectRegex); err != nil {
+			failureDetails = append(failureDetails, fmt.Sprintf("Record mismatch: %s", err.Error()))
+			log.Printf("FAILED - Record mismatch: %s", err.Error())
+			responseBody = strings.Join(records, "\n")
+			continue
+		}
+		if err := checkMaxLatency(cfg, responseTime); err != nil {
+			failureDetails = append(failureDetails, fmt.Sprintf("Error: %s", err.Error()))
+			log.Printf("FAILED - Error: %s", err.Error())
+			continue
+		}
+
+		successNum++
+		if responseTime > maxResponseTime {
+			maxResponseTime = responseTime
+		}
+		responseBody = ""
+		// Only log success details during tests to avoid exposing secrets
+		logIfTest("SUCCESS - DNS %s %s (attempt %d/%d) - Response Time: %d ms, Records: %v",
+			cfg.RecordType, cfg.ParsedURL, currentAttemptNum+1, maxRetryTimes, responseTime.Milliseconds(), records)
+		break
+	}
+	endTime := time.Now()
+
+	return checker.Endpoint{
+		URL:               cfg.URL,
+		Method:            "DNS " + cfg.RecordType,
+		Status:            getLatencyResult(getTestResult(successNum, attemptNum), cfg, maxResponseTime),
+		StartTime:         startTime.Format(time.RFC3339),
+		EndTime:           endTime.Format(time.RFC3339),
+		ResponseTime:      maxResponseTime,
+		WarnLatency:       cfg.ParsedWarnLatency,
+		AttemptNum:        attemptNum,
+		SuccessNum:        successNum,
+		FailureDetails:    failureDetails,
+		ResponseBody:      responseBody,
+		DisplayURL:        displayURL,
+		HighlightSegments: highlightSegments,
+	}
+}
+
+// newDNSResolver creates a resolver that sends every query to the given address, or the system resolver if it is empty
+func newDNSResolver(address string) *net.Resolver {
+	if address == "" {
+		return net.DefaultResolver
+	}
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, address)
+		},
+	}
+}
+
+// lookupRecords queries the records of the given type and returns them as strings
+func lookupRecords(ctx context.Context, resolver *net.Resolver, recordType, name string) ([]string, error) {
+	var records []string
+	switch recordType {
+	case "A", "AAAA":
+		network := "ip4"
+		if recordType == "AAAA" {
+			network = "ip6"
+		}
+		ips, err := resolver.LookupIP(ctx, network, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, ip := range ips {
+			records = append(records, ip.String())
+		}
+	case "CNAME":
+		cname, err := resolver.LookupCNAME(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, cname)
+	case "MX":
+		mxs, err := resolver.LookupMX(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, mx := range mxs {
+			records = append(records, mx.Host)
+		}
+	case "TXT":
+		txts, err := resolver.LookupTXT(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, txts...)
+	default:
+		return nil, fmt.Errorf("unsupported DNS record type %q", recordType)
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("no records found")
+	}
+	return records, nil
+}
+
+// matchRecords checks that every expected value is present and that at least one record matches the regex
+func matchRecords(recordType string, records, expectValues []string, expectRegex *regexp.Regexp) error {
+	for _, expected := range expectValues {
+		found := false
+		for _, record := range records {
+			if normalizeRecord(recordType, record) == normalizeRecord(recordType, expected) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("expected %q, got %v", expected, records)
+		}
+	}
+
+	if expectRegex != nil {
+		for _, record := range records {
+			if expectRegex.MatchString(record) {
+				return nil
+			}
+		}
+		return fmt.Errorf("no record matches %q, got %v", expectRegex.String(), records)
+	}
+
+	return nil
+}
+
+// normalizeRecord makes host names comparable regardless of case and the trailing dot, TXT records are kept as is
+func normalizeRecord(recordType, record string) string {
+	if recordType == "TXT" {
+		return record
+	}
+	return strings.ToLower(strings.TrimSuffix(record, "."))
+}
@@ -0,0 +1,188 @@
+package checker
+
+import (
+	"encoding/binary"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+)
+
+// DNS record types used by the test server
+const (
+	dnsTypeA     = 1
+	dnsTypeCNAME = 5
+	dnsTypeMX    = 15
+	dnsTypeTXT   = 16
+	dnsTypeAAAA  = 28
+)
+
+// dnsZone maps a lower-case name and record type to the encoded RDATA of its answers
+type dnsZone map[string]map[uint16][][]byte
+
+// encodeDNSName encodes a host name into DNS wire format
+func encodeDNSName(name string) []byte {
+	var b []byte
+	for _, label := range strings.Split(strings.TrimSuffix(name, "."), ".") {
+		b = append(b, byte(len(label)))
+		b = append(b, label...)
+	}
+	return append(b, 0)
+}
+
+// newDNSServer starts an in-process UDP DNS server answering from the zone
+func newDNSServer(t *testing.T, zone dnsZone) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if resp := answerDNSQuery(buf[:n], zone); resp != nil {
+				_, _ = conn.WriteTo(resp, addr)
+			}
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+// answerDNSQuery builds the response to a single-question query
+func answerDNSQuery(query []byte, zone dnsZone) []byte {
+	if len(query) < 12 {
+		return nil
+	}
+
+	// parse the question name
+	var labels []string
+	offset := 12
+	for offset < len(query) && query[offset] != 0 {
+		length := int(query[offset])
+		if offset+1+length > len(query) {
+			return nil
+		}
+		labels = append(labels, string(query[offset+1:offset+1+length]))
+		offset += 1 + length
+	}
+	questionEnd := offset + 5
+	if questionEnd > len(query) {
+		return nil
+	}
+	qtype := binary.BigEndian.Uint16(query[offset+1 : offset+3])
+	answers := zone[strings.ToLower(strings.Join(labels, "."))][qtype]
+
+	resp := make([]byte, 12, 512)
+	copy(resp, query[:2])
+	binary.BigEndian.PutUint16(resp[2:], 0x8180) // response, recursion desired and available
+	binary.BigEndian.PutUint16(resp[4:], 1)
+	binary.BigEndian.PutUint16(resp[6:], uint16(len(answers)))
+	resp = append(resp, query[12:questionEnd]...)
+	for _, rdata := range answers {
+		resp = append(resp, 0xc0, 0x0c) // pointer to the question name
+		resp = binary.BigEndian.AppendUint16(resp, qtype)
+		resp = binary.BigEndian.AppendUint16(resp, 1) // class IN
+		resp = binary.BigEndian.AppendUint32(resp, 60)
+		resp = binary.BigEndian.AppendUint16(resp, uint16(len(rdata)))
+		resp = append(resp, rdata...)
+	}
+	return resp
+}
+
+func TestCheckDNSEndpoint(t *testing.T) {
+	mx := binary.BigEndian.AppendUint16(nil, 10)
+	mx = append(mx, encodeDNSName("mail.example.com")...)
+	txt := "v=spf1 include:_spf.example.com ~all"
+
+	resolver := newDNSServer(t, dnsZone{
+		"example.com": {
+			dnsTypeA:    {net.ParseIP("192.0.2.10").To4(), net.ParseIP("192.0.2.11").To4()},
+			dnsTypeAAAA: {net.ParseIP("2001:db8::1")},
+			dnsTypeMX:   {mx},
+			dnsTypeTXT:  {append([]byte{byte(len(txt))}, txt...)},
+		},
+		"www.example.com": {
+			dnsTypeCNAME: {encodeDNSName("cdn.example.net")},
+		},
+	})
+
+	tests := []struct {
+		name     string
+		endpoint configure.Endpoint
+		expected chk_result.CheckResult
+	}{
+		{
+			name:     "A record resolves",
+			endpoint: configure.Endpoint{URL: "example.com", RecordType: "A"},
+			expected: chk_result.ALL,
+		},
+		{
+			name:     "A record expected value",
+			endpoint: configure.Endpoint{URL: "example.com", RecordType: "A", ExpectValues: []string{"192.0.2.11"}},
+			expected: chk_result.ALL,
+		},
+		{
+			name:     "A record unexpected value",
+			endpoint: configure.Endpoint{URL: "example.com", RecordType: "A", ExpectValues: []string{"198.51.100.1"}},
+			expected: chk_result.NONE,
+		},
+		{
+			name:     "AAAA record",
+			endpoint: configure.Endpoint{URL: "example.com", RecordType: "AAAA", ExpectValues: []string{"2001:db8::1"}},
+			expected: chk_result.ALL,
+		},
+		{
+			name:     "CNAME record",
+			endpoint: configure.Endpoint{URL: "www.example.com", RecordType: "CNAME", ExpectValues: []string{"CDN.example.net"}},
+			expected: chk_result.ALL,
+		},
+		{
+			name:     "MX record",
+			endpoint: configure.Endpoint{URL: "example.com", RecordType: "MX", ExpectValues: []string{"mail.example.com."}},
+			expected: chk_result.ALL,
+		},
+		{
+			name:     "TXT record regex",
+			endpoint: configure.Endpoint{URL: "example.com", RecordType: "TXT", ParsedExpect: "^v=spf1 "},
+			expected: chk_result.ALL,
+		},
+		{
+			name:     "TXT record regex mismatch",
+			endpoint: configure.Endpoint{URL: "example.com", RecordType: "TXT", ParsedExpect: "^v=DMARC1"},
+			expected: chk_result.NONE,
+		},
+		{
+			name:     "Unknown name",
+			endpoint: configure.Endpoint{URL: "missing.example.com", RecordType: "A"},
+			expected: chk_result.NONE,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.endpoint.Type = "dns"
+			tt.endpoint.ParsedURL = tt.endpoint.URL
+			tt.endpoint.Resolver = resolver
+
+			result := checkEndpoint(&tt.endpoint, 2, 1, "dns-test")
+
+			if result.Status != tt.expected {
+				t.Errorf("Expected status %s, got %s (failures: %v)", tt.expected, result.Status, result.FailureDetails)
+			}
+			if tt.expected == chk_result.ALL && result.ResponseTime <= 0 {
+				t.Error("Expected the resolution latency to be recorded")
+			}
+			if tt.expected == chk_result.NONE && len(result.FailureDetails) == 0 {
+				t.Error("Expected failure details for a failed check")
+			}
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package checker
 
 import (
-	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -10,13 +9,27 @@ import (
 	"strings"
 	"time"
 
-	"github.com/wcy-dt/ponghub/internal/common/params"
+	"github.com/wcy-dt/ponghub/internal/common/jsonpath"
 	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
 	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/types/endpoint_type"
+	"github.com/wcy-dt/ponghub/internal/types/types/http_method"
 )
 
 // checkEndpoint checks a single port based on the provided configuration
 func checkEndpoint(cfg *configure.Endpoint, timeout int, maxRetryTimes int, serviceName string) checker.Endpoint {
+	switch endpoint_type.ParseEndpointType(cfg.Type) {
+	case endpoint_type.TCP:
+		return checkTCPEndpoint(cfg, timeout, maxRetryTimes, serviceName)
+	case endpoint_type.DNS:
+		return checkDNSEndpoint(cfg, timeout, maxRetryTimes, serviceName)
+	default:
+		return checkHTTPEndpoint(cfg, timeout, maxRetryTimes, serviceName)
+	}
+}
+
+// checkHTTPEndpoint checks a single HTTP(S) endpoint
+func checkHTTPEndpoint(cfg *configure.Endpoint, timeout int, maxRetryTimes int, serviceName string) checker.Endpoint {
 	var failureDetails []string
 	successNum := 0
 	attemptNum := 0
@@ -24,7 +37,7 @@ func checkEndpoint(cfg *configure.Endpoint, timeout int, maxRetryTimes int, serv
 	var statusCode int
 	var responseBody string
 
-	httpMethod := getHttpMethod(cfg.Method)
+	httpMethod, methodErr := getHttpMethod(cfg.Method)
 	maxResponseTime := time.Duration(0)
 
 	// SSL certificate related variables
@@ -33,14 +46,7 @@ func checkEndpoint(cfg *configure.Endpoint, timeout int, maxRetryTimes int, serv
 	isCertExpired := false
 
 	// Generate display URL for smart showing of template vs resolved URL
-	resolver := params.NewParameterResolver()
-	displayURL, highlightSegments := resolver.HighlightChanges(cfg.URL)
-	originalURL := cfg.URL
-	if originalURL == "" {
-		originalURL = cfg.ParsedURL
-		displayURL = cfg.ParsedURL
-		highlightSegments = nil
-	}
+	displayURL, highlightSegments := getDisplayURL(cfg)
 
 	// Check SSL certificate if it's an HTTPS URL
 	if urlIsHTTPS {
@@ -64,11 +70,22 @@ func checkEndpoint(cfg *configure.Endpoint, timeout int, maxRetryTimes int, serv
 		client := &http.Client{
 			Timeout: time.Duration(timeout) * time.Second,
 		}
+		// Redirects are checked as they are if a redirect status code is expected
+		if acceptsRedirect(cfg.StatusCode) {
+			client.CheckRedirect = func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			}
+		}
 		// Only log request details during tests to avoid exposing secrets
 		logIfTest("[%s] %s %s (attempt %d/%d)\n",
 			serviceName, httpMethod, cfg.ParsedURL, currentAttemptNum+1, maxRetryTimes)
 
 		// build the request
+		if methodErr != nil {
+			failureDetails = append(failureDetails, fmt.Sprintf("StatusCode: N/A, Error: %s", methodErr.Error()))
+			log.Printf("FAILED - Error: %s", methodErr.Error())
+			continue
+		}
 		req, err := http.NewRequest(httpMethod, cfg.ParsedURL, nil)
 		if err != nil {
 			failureDetails = append(failureDetails, fmt.Sprintf("StatusCode: N/A, Error: %s", err.Error()))
@@ -91,7 +108,7 @@ func checkEndpoint(cfg *configure.Endpoint, timeout int, maxRetryTimes int, serv
 			log.Printf("FAILED - Error: %s", err.Error())
 			continue
 		}
-		body, err := io.ReadAll(resp.Body)
+		body, err := readResponseBody(httpMethod, resp)
 		if err != nil {
 			failureDetails = append(failureDetails, fmt.Sprintf("StatusCode: %d, Error: %s", resp.StatusCode, err.Error()))
 			log.Printf("FAILED - StatusCode: %d, Error: %s", resp.StatusCode, err.Error())
@@ -106,6 +123,15 @@ func checkEndpoint(cfg *configure.Endpoint, timeout int, maxRetryTimes int, serv
 
 		// check the response
 		isOnline := isSuccessfulResponse(cfg, resp, body)
+		var assertionFailures []string
+		if isOnline {
+			assertionFailures = checkHeaderAssertions(cfg.HeaderAssertions, resp.Header)
+			assertionFailures = append(assertionFailures, checkJSONAssertions(cfg.ParsedJSONAssertions, body)...)
+			if err := checkMaxLatency(cfg, responseTime); err != nil {
+				assertionFailures = append(assertionFailures, err.Error())
+			}
+			isOnline = len(assertionFailures) == 0
+		}
 		if isOnline {
 			successNum++
 			if responseTime > maxResponseTime {
@@ -121,8 +147,15 @@ func checkEndpoint(cfg *configure.Endpoint, timeout int, maxRetryTimes int, serv
 				httpMethod, cfg.ParsedURL, currentAttemptNum+1, maxRetryTimes, responseTime.Milliseconds(), resp.StatusCode)
 			break
 		}
-		failureDetails = append(failureDetails, fmt.Sprintf("StatusCode or ResponseRegex mismatch: %d", resp.StatusCode))
-		log.Printf("FAILED - StatusCode or ResponseRegex mismatch: %d", resp.StatusCode)
+		if len(assertionFailures) > 0 {
+			for _, assertionFailure := range assertionFailures {
+				failureDetails = append(failureDetails, fmt.Sprintf("StatusCode: %d, %s", resp.StatusCode, assertionFailure))
+				log.Printf("FAILED - StatusCode: %d, %s", resp.StatusCode, assertionFailure)
+			}
+		} else {
+			failureDetails = append(failureDetails, fmt.Sprintf("StatusCode or ResponseRegex mismatch: %d", resp.StatusCode))
+			log.Printf("FAILED - StatusCode or ResponseRegex mismatch: %d", resp.StatusCode)
+		}
 		if err := resp.Body.Close(); err != nil {
 			// Only log response body errors during tests to avoid exposing secrets
 			logIfTest("Error closing response body for %s: %v", cfg.ParsedURL, err)
@@ -134,11 +167,12 @@ func checkEndpoint(cfg *configure.Endpoint, timeout int, maxRetryTimes int, serv
 		URL:               cfg.URL,
 		Method:            httpMethod,
 		Body:              cfg.Body,
-		Status:            getTestResult(successNum, attemptNum),
+		Status:            getLatencyResult(getTestResult(successNum, attemptNum), cfg, maxResponseTime),
 		StatusCode:        statusCode,
 		StartTime:         startTime.Format(time.RFC3339),
 		EndTime:           endTime.Format(time.RFC3339),
 		ResponseTime:      maxResponseTime,
+		WarnLatency:       cfg.ParsedWarnLatency,
 		AttemptNum:        attemptNum,
 		SuccessNum:        successNum,
 		FailureDetails:    failureDetails,
@@ -151,31 +185,32 @@ func checkEndpoint(cfg *configure.Endpoint, timeout int, maxRetryTimes int, serv
 	}
 }
 
-// getHttpMethod converts a string method to an HTTP method constant
-func getHttpMethod(method string) string {
-	switch strings.ToUpper(method) {
-	case "GET":
-		return http.MethodGet
-	case "POST":
-		return http.MethodPost
-	case "PUT":
-		return http.MethodPut
-	case "DELETE":
-		log.Fatalln(errors.New("method not supported"))
-	case "HEAD":
-		log.Fatalln(errors.New("method not supported"))
-	case "PATCH":
-		log.Fatalln(errors.New("method not supported"))
-	case "OPTIONS":
-		log.Fatalln(errors.New("method not supported"))
-	case "TRACE":
-		log.Fatalln(errors.New("method not supported"))
-	case "CONNECT":
-		log.Fatalln(errors.New("method not supported"))
-	default:
-		return http.MethodGet // Default to GET if method is unknown
+// getHttpMethod converts a string method to an HTTP method constant.
+// Unknown methods are rejected when the config is loaded, the error only guards hand-built configs.
+func getHttpMethod(method string) (string, error) {
+	httpMethod, err := http_method.ParseHTTPMethod(method)
+	if err != nil {
+		return strings.ToUpper(method), err
 	}
-	return http.MethodGet
+	return httpMethod, nil
+}
+
+// readResponseBody reads the response body, HEAD responses have no body and are not read
+func readResponseBody(httpMethod string, resp *http.Response) ([]byte, error) {
+	if !http_method.HasResponseBody(httpMethod) {
+		return nil, nil
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// acceptsRedirect checks if any of the expected status codes is a redirect
+func acceptsRedirect(statusCodes configure.StatusCodes) bool {
+	for _, codeRange := range statusCodes {
+		if codeRange.Min < 400 && codeRange.Max >= 300 {
+			return true
+		}
+	}
+	return false
 }
 
 // isSuccessfulResponse checks if the response from the server is successful based on the configuration
@@ -192,19 +227,39 @@ func isSuccessfulResponse(cfg *configure.Endpoint, rsp *http.Response, body []by
 	}
 
 	// statusCode and responseRegex are not set, and the response is OK
-	if cfg.StatusCode == 0 && cfg.ResponseRegex == "" && rsp.StatusCode == http.StatusOK {
+	if cfg.StatusCode.IsEmpty() && cfg.ResponseRegex == "" && rsp.StatusCode == http.StatusOK {
 		return true
 	}
 
 	// statusCode is not set, and the responseRegex matches
-	if cfg.StatusCode == 0 && cfg.ResponseRegex != "" {
+	if cfg.StatusCode.IsEmpty() && cfg.ResponseRegex != "" {
 		return true
 	}
 
-	// statusCode is set, and the response matches the expected status code
-	if cfg.StatusCode != 0 && rsp.StatusCode == cfg.StatusCode {
+	// statusCode is set, and the response matches one of the expected status codes
+	if !cfg.StatusCode.IsEmpty() && cfg.StatusCode.Contains(rsp.StatusCode) {
 		return true
 	}
 
 	return false
 }
+
+// checkJSONAssertions evaluates the JSON assertions against the response body and returns a message for every failed one
+func checkJSONAssertions(assertions []*jsonpath.Assertion, body []byte) []string {
+	if len(assertions) == 0 {
+		return nil
+	}
+
+	doc, err := jsonpath.ParseDocument(body)
+	if err != nil {
+		return []string{fmt.Sprintf("JSON assertion error: %s", err.Error())}
+	}
+
+	var failures []string
+	for _, assertion := range assertions {
+		if ok, actual := assertion.Evaluate(doc); !ok {
+			failures = append(failures, fmt.Sprintf("JSON assertion failed: %s (actual: %s)", assertion, actual))
+		}
+	}
+	return failures
+}
@@ -0,0 +1,303 @@
+package checker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/common/jsonpath"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+)
+
+func TestCheckHTTPEndpoint_JSONAssertions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status": "ok", "version": "2.1.0", "checks": [{"healthy": true}, {"healthy": false}]}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		assertions []string
+		expected   chk_result.CheckResult
+		failures   []string
+	}{
+		{
+			name:       "All assertions hold",
+			assertions: []string{`$.status == "ok"`, `$.version matches ^2\.`},
+			expected:   chk_result.ALL,
+		},
+		{
+			name:       "Unhealthy sub-dependency",
+			assertions: []string{`$.status == "ok"`, `$.checks[*].healthy all true`, `$.version matches ^3\.`},
+			expected:   chk_result.NONE,
+			failures: []string{
+				`JSON assertion failed: $.checks[*].healthy all true (actual: [true,false])`,
+				`JSON assertion failed: $.version matches ^3\. (actual: "2.1.0")`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint := configure.Endpoint{URL: server.URL, ParsedURL: server.URL, ParsedJSONAssertions: parseAssertions(t, tt.assertions...)}
+			result := checkEndpoint(&endpoint, 5, 1, "json-test")
+
+			if result.Status != tt.expected {
+				t.Errorf("Expected status %s, got %s (failures: %v)", tt.expected, result.Status, result.FailureDetails)
+			}
+			if len(result.FailureDetails) != len(tt.failures) {
+				t.Fatalf("Expected %d failure details, got %v", len(tt.failures), result.FailureDetails)
+			}
+			for i, failure := range tt.failures {
+				if !strings.HasSuffix(result.FailureDetails[i], failure) {
+					t.Errorf("Expected failure detail %q, got %q", failure, result.FailureDetails[i])
+				}
+			}
+		})
+	}
+}
+
+// parseAssertions parses the JSON assertions like the config does when it is loaded
+func parseAssertions(t *testing.T, exprs ...string) []*jsonpath.Assertion {
+	t.Helper()
+	assertions := make([]*jsonpath.Assertion, len(exprs))
+	for i, expr := range exprs {
+		assertion, err := jsonpath.ParseAssertion(expr)
+		if err != nil {
+			t.Fatalf("Failed to parse assertion %q: %v", expr, err)
+		}
+		assertions[i] = assertion
+	}
+	return assertions
+}
+
+func TestCheckHTTPEndpoint_JSONAssertionsInvalidBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<html>not json</html>"))
+	}))
+	defer server.Close()
+
+	endpoint := configure.Endpoint{URL: server.URL, ParsedURL: server.URL, ParsedJSONAssertions: parseAssertions(t, `$.status == "ok"`)}
+	result := checkEndpoint(&endpoint, 5, 1, "json-test")
+
+	if result.Status != chk_result.NONE {
+		t.Errorf("Expected status %s, got %s", chk_result.NONE, result.Status)
+	}
+	if len(result.FailureDetails) != 1 || !strings.Contains(result.FailureDetails[0], "not valid JSON") {
+		t.Errorf("Expected a JSON parse failure, got %v", result.FailureDetails)
+	}
+}
+
+func TestCheckHTTPEndpoint_StatusCodes(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	mux.HandleFunc("/empty", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusMovedPermanently)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		path        string
+		statusCodes configure.StatusCodes
+		expected    chk_result.CheckResult
+	}{
+		{"Default accepts 200", "/ok", nil, chk_result.ALL},
+		{"Default rejects 204", "/empty", nil, chk_result.NONE},
+		{"List accepts 204", "/empty", configure.StatusCodes{{Min: 200, Max: 200}, {Min: 204, Max: 204}}, chk_result.ALL},
+		{"Class accepts 204", "/empty", configure.StatusCodes{{Min: 200, Max: 299}}, chk_result.ALL},
+		{"Redirect range is not followed", "/redirect", configure.StatusCodes{{Min: 301, Max: 308}}, chk_result.ALL},
+		{"Redirect is followed otherwise", "/redirect", configure.StatusCodes{{Min: 200, Max: 200}}, chk_result.ALL},
+		{"Range rejects 200", "/ok", configure.StatusCodes{{Min: 301, Max: 308}}, chk_result.NONE},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url := server.URL + tt.path
+			endpoint := configure.Endpoint{URL: url, ParsedURL: url, StatusCode: tt.statusCodes}
+			result := checkEndpoint(&endpoint, 5, 1, "status-test")
+
+			if result.Status != tt.expected {
+				t.Errorf("Expected status %s, got %s (failures: %v)", tt.expected, result.Status, result.FailureDetails)
+			}
+		})
+	}
+}
+
+func TestCheckHTTPEndpoint_HeaderAssertions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Header().Set("Server", "nginx/1.25.3")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		assertions []configure.HeaderAssertion
+		failures   []string
+	}{
+		{
+			name: "All assertions hold",
+			assertions: []configure.HeaderAssertion{
+				{Name: "Content-Type", Equals: "application/json"},
+				{Name: "cache-control", Present: true},
+				{Name: "Cache-Control", Regex: `max-age=\d+`, ParsedRegex: regexp.MustCompile(`max-age=\d+`)},
+				{Name: "X-Powered-By", Absent: true},
+			},
+		},
+		{
+			name: "Failing assertions",
+			assertions: []configure.HeaderAssertion{
+				{Name: "Content-Type", Equals: "text/html"},
+				{Name: "Server", NotRegex: `/[0-9.]+`, ParsedRegex: regexp.MustCompile(`/[0-9.]+`)},
+				{Name: "Strict-Transport-Security", Present: true},
+				{Name: "Server", Absent: true},
+			},
+			failures: []string{
+				`Header assertion failed: Content-Type equals "text/html" (actual: "application/json")`,
+				`Header assertion failed: Server does not match "/[0-9.]+" (actual: "nginx/1.25.3")`,
+				`Header assertion failed: Strict-Transport-Security present (actual: <missing>)`,
+				`Header assertion failed: Server absent (actual: "nginx/1.25.3")`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint := configure.Endpoint{URL: server.URL, ParsedURL: server.URL, HeaderAssertions: tt.assertions}
+			result := checkEndpoint(&endpoint, 5, 1, "header-test")
+
+			expected := chk_result.ALL
+			if len(tt.failures) > 0 {
+				expected = chk_result.NONE
+			}
+			if result.Status != expected {
+				t.Errorf("Expected status %s, got %s (failures: %v)", expected, result.Status, result.FailureDetails)
+			}
+			if len(result.FailureDetails) != len(tt.failures) {
+				t.Fatalf("Expected %d failure details, got %v", len(tt.failures), result.FailureDetails)
+			}
+			for i, failure := range tt.failures {
+				if !strings.HasSuffix(result.FailureDetails[i], failure) {
+					t.Errorf("Expected failure detail %q, got %q", failure, result.FailureDetails[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCheckHTTPEndpoint_Methods(t *testing.T) {
+	var receivedMethod string
+	var receivedHeaders http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedMethod = r.Method
+		receivedHeaders = r.Header
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Origin", r.Header.Get("Origin"))
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_, _ = w.Write([]byte("pong"))
+	}))
+	defer server.Close()
+
+	for _, method := range []string{"get", "HEAD", "POST", "PUT", "PATCH", "DELETE", "TRACE"} {
+		t.Run(method, func(t *testing.T) {
+			endpoint := configure.Endpoint{URL: server.URL, ParsedURL: server.URL, Method: method}
+			result := checkEndpoint(&endpoint, 5, 1, "method-test")
+
+			if result.Status != chk_result.ALL {
+				t.Errorf("Expected status %s, got %s (failures: %v)", chk_result.ALL, result.Status, result.FailureDetails)
+			}
+			if receivedMethod != strings.ToUpper(method) {
+				t.Errorf("Expected the server to receive %s, got %s", strings.ToUpper(method), receivedMethod)
+			}
+		})
+	}
+
+	t.Run("CORS preflight", func(t *testing.T) {
+		endpoint := configure.Endpoint{
+			URL:       server.URL,
+			ParsedURL: server.URL,
+			Method:    "OPTIONS",
+			ParsedHeaders: map[string]string{
+				"Origin":                        "https://app.example.com",
+				"Access-Control-Request-Method": "POST",
+			},
+			StatusCode: configure.StatusCodes{{Min: 204, Max: 204}},
+			HeaderAssertions: []configure.HeaderAssertion{
+				{Name: "Access-Control-Allow-Origin", Equals: "https://app.example.com"},
+				{Name: "Access-Control-Allow-Methods", Regex: `\bPOST\b`, ParsedRegex: regexp.MustCompile(`\bPOST\b`)},
+			},
+		}
+		result := checkEndpoint(&endpoint, 5, 1, "method-test")
+
+		if result.Status != chk_result.ALL {
+			t.Errorf("Expected status %s, got %s (failures: %v)", chk_result.ALL, result.Status, result.FailureDetails)
+		}
+		if receivedHeaders.Get("Access-Control-Request-Method") != "POST" {
+			t.Errorf("Expected the preflight request headers to be sent, got %v", receivedHeaders)
+		}
+	})
+
+	t.Run("Unknown method", func(t *testing.T) {
+		endpoint := configure.Endpoint{URL: server.URL, ParsedURL: server.URL, Method: "FETCH"}
+		result := checkEndpoint(&endpoint, 5, 2, "method-test")
+
+		if result.Status != chk_result.NONE {
+			t.Errorf("Expected status %s, got %s", chk_result.NONE, result.Status)
+		}
+		if len(result.FailureDetails) != 2 || !strings.Contains(result.FailureDetails[0], "unsupported HTTP method") {
+			t.Errorf("Expected unsupported method failures, got %v", result.FailureDetails)
+		}
+	})
+}
+
+func TestCheckHTTPEndpoint_LatencyThresholds(t *testing.T) {
+	var inFlight, peak int32
+	server := newSlowServer(t, 100*time.Millisecond, &inFlight, &peak)
+
+	tests := []struct {
+		name       string
+		warn       time.Duration
+		max        time.Duration
+		expected   chk_result.CheckResult
+		failureMsg string
+	}{
+		{name: "Below thresholds", warn: 2 * time.Second, max: 5 * time.Second, expected: chk_result.ALL},
+		{name: "Above warn_latency", warn: 20 * time.Millisecond, max: 5 * time.Second, expected: chk_result.DEGRADED},
+		{name: "Above max_latency", warn: 10 * time.Millisecond, max: 20 * time.Millisecond, expected: chk_result.NONE, failureMsg: "exceeds max_latency 20ms"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint := configure.Endpoint{
+				URL:               server.URL,
+				ParsedURL:         server.URL,
+				ParsedWarnLatency: tt.warn,
+				ParsedMaxLatency:  tt.max,
+			}
+			result := checkEndpoint(&endpoint, 5, 1, "latency-test")
+
+			if result.Status != tt.expected {
+				t.Errorf("Expected status %s, got %s (failures: %v)", tt.expected, result.Status, result.FailureDetails)
+			}
+			if tt.failureMsg != "" && (len(result.FailureDetails) != 1 || !strings.Contains(result.FailureDetails[0], tt.failureMsg)) {
+				t.Errorf("Expected a failure containing %q, got %v", tt.failureMsg, result.FailureDetails)
+			}
+			if result.WarnLatency != tt.warn {
+				t.Errorf("Expected warn latency %v in the result, got %v", tt.warn, result.WarnLatency)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package checker
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+)
+
+// checkHeaderAssertions evaluates the header assertions against the response headers and returns a message for every failed one
+func checkHeaderAssertions(assertions []configure.HeaderAssertion, header http.Header) []string {
+	var failures []string
+	for _, assertion := range assertions {
+		values, present := header[http.CanonicalHeaderKey(assertion.Name)]
+		actual := "<missing>"
+		if present {
+			actual = fmt.Sprintf("%q", header.Get(assertion.Name))
+		}
+
+		ok := false
+		var check string
+		switch {
+		case assertion.Present:
+			ok, check = present, "present"
+		case assertion.Absent:
+			ok, check = !present, "absent"
+		case assertion.Equals != "":
+			ok, check = present && containsValue(values, assertion.Equals), fmt.Sprintf("equals %q", assertion.Equals)
+		case assertion.Regex != "":
+			ok, check = present && matchesAny(assertion.ParsedRegex, values), fmt.Sprintf("matches %q", assertion.Regex)
+		case assertion.NotRegex != "":
+			ok, check = !matchesAny(assertion.ParsedRegex, values), fmt.Sprintf("does not match %q", assertion.NotRegex)
+		}
+
+		if !ok {
+			failures = append(failures, fmt.Sprintf("Header assertion failed: %s %s (actual: %s)", assertion.Name, check, actual))
+		}
+	}
+	return failures
+}
+
+// containsValue checks if one of the header values equals the expected value
+func containsValue(values []string, expected string) bool {
+	for _, value := range values {
+		if value == expected {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAny checks if one of the header values matches the regex
+func matchesAny(re *regexp.Regexp, values []string) bool {
+	for _, value := range values {
+		if re.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package checker
 
 import (
+	"sync"
 	"time"
 
 	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
@@ -8,40 +9,77 @@ import (
 	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
 )
 
-// CheckServices checks all services defined in the configuration
+// CheckServices checks all services defined in the configuration.
+// Services and endpoints are checked concurrently, but the results keep the config order.
 func CheckServices(cfg *configure.Configure) []checker.Service {
-	var checkResult []checker.Service
-	for _, service := range cfg.Services {
-		attemptNum := 0
-		successNum := 0
-		endpointNum := 0
-		onlineEndpointNum := 0
-
-		// check Endpoints ports
-		startTime := time.Now()
-		var endpointResults []checker.Endpoint
-		for _, endpoint := range service.Endpoints {
-			endpointResult := checkEndpoint(&endpoint, service.Timeout, service.MaxRetryTimes, service.Name)
-			endpointResults = append(endpointResults, endpointResult)
-			attemptNum += endpointResult.AttemptNum
-			successNum += endpointResult.SuccessNum
-			endpointNum++
-			if endpointResult.Status == chk_result.ALL {
-				onlineEndpointNum++
-			}
-		}
-		endTime := time.Now()
-
-		serviceResult := checker.Service{
-			Name:       service.Name,
-			Status:     getTestResult(onlineEndpointNum, endpointNum),
-			Endpoints:  endpointResults,
-			StartTime:  startTime.Format(time.RFC3339),
-			EndTime:    endTime.Format(time.RFC3339),
-			AttemptNum: attemptNum,
-			SuccessNum: successNum,
-		}
-		checkResult = append(checkResult, serviceResult)
+	checkResult := make([]checker.Service, len(cfg.Services))
+
+	// globalPool bounds the number of endpoint checks in flight across all services
+	globalPool := make(chan struct{}, cfg.Concurrency)
+
+	var wg sync.WaitGroup
+	for i := range cfg.Services {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			checkResult[i] = checkService(&cfg.Services[i], globalPool)
+		}(i)
 	}
+	wg.Wait()
+
 	return checkResult
 }
+
+// checkService checks all endpoints of a single service using the service's own worker pool
+func checkService(service *configure.Service, globalPool chan struct{}) checker.Service {
+	attemptNum := 0
+	successNum := 0
+
+	// servicePool bounds the number of endpoint checks in flight for this service
+	servicePool := make(chan struct{}, service.Concurrency)
+
+	// check Endpoints ports
+	startTime := time.Now()
+	endpointResults := make([]checker.Endpoint, len(service.Endpoints))
+	var wg sync.WaitGroup
+	for j := range service.Endpoints {
+		wg.Add(1)
+		go func(j int) {
+			defer wg.Done()
+
+			// acquire the service slot first, then the global slot, so the order is always the same
+			servicePool <- struct{}{}
+			defer func() { <-servicePool }()
+			globalPool <- struct{}{}
+			defer func() { <-globalPool }()
+
+			endpointResults[j] = checkEndpoint(&service.Endpoints[j], service.Timeout, service.MaxRetryTimes, service.Name)
+		}(j)
+	}
+	wg.Wait()
+	endTime := time.Now()
+
+	for _, endpointResult := range endpointResults {
+		attemptNum += endpointResult.AttemptNum
+		successNum += endpointResult.SuccessNum
+	}
+
+	// checks during a maintenance window are kept for the report, but count neither as up nor as down
+	status := getServiceResult(endpointResults)
+	if service.Maintenance.IsActive(startTime) {
+		status = chk_result.MAINTENANCE
+		for j := range endpointResults {
+			endpointResults[j].Status = chk_result.MAINTENANCE
+		}
+	}
+
+	return checker.Service{
+		Name:       service.Name,
+		Status:     status,
+		Endpoints:  endpointResults,
+		StartTime:  startTime.Format(time.RFC3339),
+		EndTime:    endTime.Format(time.RFC3339),
+		AttemptNum: attemptNum,
+		SuccessNum: successNum,
+	}
+}
@@ -0,0 +1,187 @@
+package checker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+)
+
+// newSlowServer starts a test server that sleeps before answering and tracks the peak number of concurrent requests
+func newSlowServer(t *testing.T, delay time.Duration, inFlight, peak *int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(inFlight, 1)
+		defer atomic.AddInt32(inFlight, -1)
+		for {
+			old := atomic.LoadInt32(peak)
+			if current <= old || atomic.CompareAndSwapInt32(peak, old, current) {
+				break
+			}
+		}
+		time.Sleep(delay)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newServiceConfig builds a service config with n endpoints pointing at the given server
+func newServiceConfig(name, url string, n, concurrency int) configure.Service {
+	service := configure.Service{
+		Name:          name,
+		Timeout:       5,
+		MaxRetryTimes: 1,
+		Concurrency:   concurrency,
+	}
+	for i := 0; i < n; i++ {
+		endpointURL := url + "/" + name + "/" + string(rune('a'+i))
+		service.Endpoints = append(service.Endpoints, configure.Endpoint{
+			URL:       endpointURL,
+			ParsedURL: endpointURL,
+		})
+	}
+	return service
+}
+
+func TestCheckServices_PreservesOrder(t *testing.T) {
+	var inFlight, peak int32
+	server := newSlowServer(t, 20*time.Millisecond, &inFlight, &peak)
+
+	cfg := &configure.Configure{
+		Concurrency: 8,
+		Services: []configure.Service{
+			newServiceConfig("first", server.URL, 4, 8),
+			newServiceConfig("second", server.URL, 4, 8),
+			newServiceConfig("third", server.URL, 4, 8),
+		},
+	}
+
+	result := CheckServices(cfg)
+
+	if len(result) != len(cfg.Services) {
+		t.Fatalf("Expected %d services, got %d", len(cfg.Services), len(result))
+	}
+	for i, service := range result {
+		if service.Name != cfg.Services[i].Name {
+			t.Errorf("Expected service %d to be %s, got %s", i, cfg.Services[i].Name, service.Name)
+		}
+		if service.Status != chk_result.ALL {
+			t.Errorf("Expected service %s to be %s, got %s", service.Name, chk_result.ALL, service.Status)
+		}
+		for j, endpoint := range service.Endpoints {
+			if endpoint.URL != cfg.Services[i].Endpoints[j].URL {
+				t.Errorf("Expected endpoint %d of %s to be %s, got %s", j, service.Name, cfg.Services[i].Endpoints[j].URL, endpoint.URL)
+			}
+			if endpoint.StartTime == "" {
+				t.Errorf("Expected endpoint %s to have a start time", endpoint.URL)
+			}
+		}
+	}
+}
+
+func TestCheckServices_GlobalConcurrencyLimit(t *testing.T) {
+	var inFlight, peak int32
+	server := newSlowServer(t, 50*time.Millisecond, &inFlight, &peak)
+
+	cfg := &configure.Configure{
+		Concurrency: 3,
+		Services: []configure.Service{
+			newServiceConfig("first", server.URL, 4, 3),
+			newServiceConfig("second", server.URL, 4, 3),
+		},
+	}
+
+	start := time.Now()
+	CheckServices(cfg)
+	elapsed := time.Since(start)
+
+	if peak > 3 {
+		t.Errorf("Expected at most 3 concurrent checks, got %d", peak)
+	}
+	if peak < 2 {
+		t.Errorf("Expected endpoints to be checked concurrently, peak was %d", peak)
+	}
+	if elapsed >= 8*50*time.Millisecond {
+		t.Errorf("Expected concurrent checks to be faster than sequential ones, took %v", elapsed)
+	}
+}
+
+func TestCheckServices_ServiceConcurrencyLimit(t *testing.T) {
+	var inFlight, peak int32
+	server := newSlowServer(t, 30*time.Millisecond, &inFlight, &peak)
+
+	cfg := &configure.Configure{
+		Concurrency: 10,
+		Services: []configure.Service{
+			newServiceConfig("sequential", server.URL, 4, 1),
+		},
+	}
+
+	CheckServices(cfg)
+
+	if peak != 1 {
+		t.Errorf("Expected endpoints of a service with concurrency 1 to be checked one at a time, peak was %d", peak)
+	}
+}
+
+func TestGetServiceResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []chk_result.CheckResult
+		expected chk_result.CheckResult
+	}{
+		{name: "All online", statuses: []chk_result.CheckResult{chk_result.ALL, chk_result.ALL}, expected: chk_result.ALL},
+		{name: "One degraded", statuses: []chk_result.CheckResult{chk_result.ALL, chk_result.DEGRADED}, expected: chk_result.DEGRADED},
+		{name: "Degraded and down", statuses: []chk_result.CheckResult{chk_result.DEGRADED, chk_result.NONE}, expected: chk_result.PART},
+		{name: "All down", statuses: []chk_result.CheckResult{chk_result.NONE, chk_result.NONE}, expected: chk_result.NONE},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var endpointResults []checker.Endpoint
+			for _, status := range tt.statuses {
+				endpointResults = append(endpointResults, checker.Endpoint{Status: status})
+			}
+			if result := getServiceResult(endpointResults); result != tt.expected {
+				t.Errorf("Expected status %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCheckServices_Maintenance(t *testing.T) {
+	var inFlight, peak int32
+	server := newSlowServer(t, 0, &inFlight, &peak)
+
+	inMaintenance := newServiceConfig("deploying", server.URL, 2, 2)
+	inMaintenance.Maintenance = configure.MaintenanceWindows{
+		{ParsedStart: time.Now().Add(-time.Hour), ParsedEnd: time.Now().Add(time.Hour)},
+	}
+	finished := newServiceConfig("deployed", server.URL, 1, 1)
+	finished.Maintenance = configure.MaintenanceWindows{
+		{ParsedStart: time.Now().Add(-2 * time.Hour), ParsedEnd: time.Now().Add(-time.Hour)},
+	}
+	cfg := &configure.Configure{
+		Concurrency: 4,
+		Services:    []configure.Service{inMaintenance, finished},
+	}
+
+	result := CheckServices(cfg)
+
+	if result[0].Status != chk_result.MAINTENANCE {
+		t.Errorf("Expected the service in maintenance to be %s, got %s", chk_result.MAINTENANCE, result[0].Status)
+	}
+	for _, endpoint := range result[0].Endpoints {
+		if endpoint.Status != chk_result.MAINTENANCE || endpoint.SuccessNum != 1 {
+			t.Errorf("Expected %s to be checked and marked %s, got %+v", endpoint.URL, chk_result.MAINTENANCE, endpoint)
+		}
+	}
+	if result[1].Status != chk_result.ALL {
+		t.Errorf("Expected the service after its window to be %s, got %s", chk_result.ALL, result[1].Status)
+	}
+}
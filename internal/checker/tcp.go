@@ -0,0 +1,131 @@
+package checker
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"regexp"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+)
+
+// maxBannerSize is the maximum number of bytes read from a TCP endpoint when matching the expected banner
+const maxBannerSize = 4096
+
+// checkTCPEndpoint checks that a TCP port accepts connections and optionally answers with the expected banner
+func checkTCPEndpoint(cfg *configure.Endpoint, timeout int, maxRetryTimes int, serviceName string) checker.Endpoint {
+	var failureDetails []string
+	successNum := 0
+	attemptNum := 0
+
+	var responseBody string
+	maxResponseTime := time.Duration(0)
+
+	displayURL, highlightSegments := getDisplayURL(cfg)
+
+	var expectRegex *regexp.Regexp
+	if cfg.ParsedExpect != "" {
+		expectRegex = regexp.MustCompile(cfg.ParsedExpect)
+	}
+
+	startTime := time.Now()
+	for currentAttemptNum := range maxRetryTimes {
+		attemptNum++
+		// Only log request details during tests to avoid exposing secrets
+		logIfTest("[%s] TCP %s (attempt %d/%d)\n",
+			serviceName, cfg.ParsedURL, currentAttemptNum+1, maxRetryTimes)
+
+		connectTime, banner, err := probeTCP(cfg.ParsedURL, cfg.ParsedSend, expectRegex, time.Duration(timeout)*time.Second)
+		if err != nil {
+			failureDetails = append(failureDetails, fmt.Sprintf("Error: %s", err.Error()))
+			log.Printf("FAILED - Error: %s", err.Error())
+			responseBody = banner
+			continue
+		}
+		if err := checkMaxLatency(cfg, connectTime); err != nil {
+			failureDetails = append(failureDetails, fmt.Sprintf("Error: %s", err.Error()))
+			log.Printf("FAILED - Error: %s", err.Error())
+			continue
+		}
+
+		successNum++
+		if connectTime > maxResponseTime {
+			maxResponseTime = connectTime
+		}
+		responseBody = ""
+		// Only log success details during tests to avoid exposing secrets
+		logIfTest("SUCCESS - TCP %s (attempt %d/%d) - Connect Time: %d ms",
+			cfg.ParsedURL, currentAttemptNum+1, maxRetryTimes, connectTime.Milliseconds())
+		break
+	}
+	endTime := time.Now()
+
+	return checker.Endpoint{
+		URL:               cfg.URL,
+		Method:            "TCP",
+		Body:              cfg.Send,
+		Status:            getLatencyResult(getTestResult(successNum, attemptNum), cfg, maxResponseTime),
+		StartTime:         startTime.Format(time.RFC3339),
+		EndTime:           endTime.Format(time.RFC3339),
+		ResponseTime:      maxResponseTime,
+		WarnLatency:       cfg.ParsedWarnLatency,
+		AttemptNum:        attemptNum,
+		SuccessNum:        successNum,
+		FailureDetails:    failureDetails,
+		ResponseBody:      responseBody,
+		DisplayURL:        displayURL,
+		HighlightSegments: highlightSegments,
+	}
+}
+
+// probeTCP connects to the address, sends the payload and waits for the expected banner.
+// It returns the connect time and, on a banner mismatch, the data received so far.
+func probeTCP(address, send string, expectRegex *regexp.Regexp, timeout time.Duration) (time.Duration, string, error) {
+	connStartTime := time.Now()
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	connectTime := time.Since(connStartTime)
+	if err != nil {
+		return 0, "", err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			logIfTest("Error closing TCP connection to %s: %v", address, err)
+		}
+	}()
+
+	if send == "" && expectRegex == nil {
+		return connectTime, "", nil
+	}
+
+	if err := conn.SetDeadline(connStartTime.Add(timeout)); err != nil {
+		return 0, "", err
+	}
+
+	if send != "" {
+		if _, err := conn.Write([]byte(send)); err != nil {
+			return 0, "", fmt.Errorf("failed to send payload: %w", err)
+		}
+	}
+
+	if expectRegex == nil {
+		return connectTime, "", nil
+	}
+
+	// read until the banner matches, the connection is closed or the deadline is reached
+	banner := make([]byte, 0, maxBannerSize)
+	buf := make([]byte, 512)
+	for len(banner) < maxBannerSize {
+		n, err := conn.Read(buf)
+		banner = append(banner, buf[:n]...)
+		if expectRegex.Match(banner) {
+			return connectTime, "", nil
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	return 0, string(banner), fmt.Errorf("expected banner %q not received", expectRegex.String())
+}
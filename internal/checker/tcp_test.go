@@ -0,0 +1,104 @@
+package checker
+
+import (
+	"bufio"
+	"net"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+)
+
+// newTCPServer starts a TCP server that writes the banner and then echoes every line it receives
+func newTCPServer(t *testing.T, banner string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer func() { _ = conn.Close() }()
+				if banner != "" {
+					_, _ = conn.Write([]byte(banner))
+				}
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					_, _ = conn.Write([]byte("echo: " + scanner.Text() + "\n"))
+				}
+			}(conn)
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+// closedTCPAddress returns an address on which nothing is listening
+func closedTCPAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	address := listener.Addr().String()
+	_ = listener.Close()
+	return address
+}
+
+func TestCheckTCPEndpoint(t *testing.T) {
+	bannerAddress := newTCPServer(t, "SSH-2.0-OpenSSH_9.6\r\n")
+	silentAddress := newTCPServer(t, "")
+
+	tests := []struct {
+		name     string
+		endpoint configure.Endpoint
+		expected chk_result.CheckResult
+	}{
+		{
+			name:     "Port open",
+			endpoint: configure.Endpoint{Type: "tcp", URL: silentAddress, ParsedURL: silentAddress},
+			expected: chk_result.ALL,
+		},
+		{
+			name:     "Port closed",
+			endpoint: configure.Endpoint{Type: "tcp", URL: "closed", ParsedURL: closedTCPAddress(t)},
+			expected: chk_result.NONE,
+		},
+		{
+			name:     "Banner matches",
+			endpoint: configure.Endpoint{Type: "tcp", URL: bannerAddress, ParsedURL: bannerAddress, ParsedExpect: "^SSH-2\\.0"},
+			expected: chk_result.ALL,
+		},
+		{
+			name:     "Banner mismatch",
+			endpoint: configure.Endpoint{Type: "tcp", URL: bannerAddress, ParsedURL: bannerAddress, ParsedExpect: "^220 "},
+			expected: chk_result.NONE,
+		},
+		{
+			name:     "Send and expect",
+			endpoint: configure.Endpoint{Type: "tcp", URL: silentAddress, ParsedURL: silentAddress, ParsedSend: "PING\n", ParsedExpect: "echo: PING"},
+			expected: chk_result.ALL,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkEndpoint(&tt.endpoint, 1, 2, "tcp-test")
+
+			if result.Status != tt.expected {
+				t.Errorf("Expected status %s, got %s (failures: %v)", tt.expected, result.Status, result.FailureDetails)
+			}
+			if result.Method != "TCP" {
+				t.Errorf("Expected method TCP, got %s", result.Method)
+			}
+			if tt.expected == chk_result.NONE && len(result.FailureDetails) == 0 {
+				t.Error("Expected failure details for a failed check")
+			}
+		})
+	}
+}
@@ -1,11 +1,17 @@
 package checker
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/wcy-dt/ponghub/internal/common/params"
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
 	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+	"github.com/wcy-dt/ponghub/internal/types/types/highlight"
 )
 
 // isTestMode checks if the current execution is in test mode
@@ -39,3 +45,49 @@ func getTestResult(successNum, attemptNum int) chk_result.CheckResult {
 		return chk_result.PART
 	}
 }
+
+// checkMaxLatency returns an error if the response time exceeds the max_latency of the endpoint
+func checkMaxLatency(cfg *configure.Endpoint, responseTime time.Duration) error {
+	if cfg.ParsedMaxLatency > 0 && responseTime > cfg.ParsedMaxLatency {
+		return fmt.Errorf("latency %d ms exceeds max_latency %s", responseTime.Milliseconds(), cfg.ParsedMaxLatency)
+	}
+	return nil
+}
+
+// getLatencyResult downgrades a fully successful result to DEGRADED if the slowest successful attempt exceeds warn_latency
+func getLatencyResult(result chk_result.CheckResult, cfg *configure.Endpoint, responseTime time.Duration) chk_result.CheckResult {
+	if result == chk_result.ALL && cfg.ParsedWarnLatency > 0 && responseTime > cfg.ParsedWarnLatency {
+		return chk_result.DEGRADED
+	}
+	return result
+}
+
+// getServiceResult determines the service result from its endpoint results,
+// a service is degraded if every endpoint is online and at least one of them is degraded
+func getServiceResult(endpointResults []checker.Endpoint) chk_result.CheckResult {
+	onlineEndpointNum := 0
+	isDegraded := false
+	for _, endpointResult := range endpointResults {
+		if endpointResult.Status.IsOnline() {
+			onlineEndpointNum++
+		}
+		if endpointResult.Status == chk_result.DEGRADED {
+			isDegraded = true
+		}
+	}
+
+	result := getTestResult(onlineEndpointNum, len(endpointResults))
+	if result == chk_result.ALL && isDegraded {
+		return chk_result.DEGRADED
+	}
+	return result
+}
+
+// getDisplayURL generates the display URL and its highlight segments for smart showing of template vs resolved URL
+func getDisplayURL(cfg *configure.Endpoint) (string, []highlight.Segment) {
+	if cfg.URL == "" {
+		return cfg.ParsedURL, nil
+	}
+	resolver := params.NewParameterResolver()
+	return resolver.HighlightChanges(cfg.URL)
+}
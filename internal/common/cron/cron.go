@@ -0,0 +1,151 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// field describes the allowed range of a cron field
+type field struct {
+	name string
+	min  int
+	max  int
+}
+
+// fields lists the five fields of a cron expression in order
+var fields = []field{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7},
+}
+
+// Schedule is a compiled cron expression with the fields `minute hour day-of-month month day-of-week`
+type Schedule struct {
+	expr   string
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	anyDom bool
+	anyDow bool
+}
+
+// Parse compiles a standard five-field cron expression such as `0 2 * * 0` or `*/15 9-17 * * 1-5`.
+// Every field supports `*`, single values, ranges `a-b`, steps `/n` and lists separated by commas.
+func Parse(expr string) (*Schedule, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("cron expression %q must have %d fields", expr, len(fields))
+	}
+
+	schedule := &Schedule{expr: expr}
+	sets := []*uint64{&schedule.minute, &schedule.hour, &schedule.dom, &schedule.month, &schedule.dow}
+	for i, part := range parts {
+		set, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("cron expression %q: %w", expr, err)
+		}
+		*sets[i] = set
+	}
+
+	// both 0 and 7 mean Sunday
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1
+	}
+	schedule.anyDom = parts[2] == "*"
+	schedule.anyDow = parts[4] == "*"
+	return schedule, nil
+}
+
+// parseField parses a single cron field into a bit set of the allowed values
+func parseField(part string, f field) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(part, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepExpr)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q in %s", stepExpr, f.name)
+			}
+		}
+
+		lower, upper := f.min, f.max
+		if rangeExpr != "*" {
+			lowerExpr, upperExpr, isRange := strings.Cut(rangeExpr, "-")
+			var err error
+			if lower, err = parseValue(lowerExpr, f); err != nil {
+				return 0, err
+			}
+			upper = lower
+			if isRange {
+				if upper, err = parseValue(upperExpr, f); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				upper = f.max
+			}
+			if lower > upper {
+				return 0, fmt.Errorf("invalid range %q in %s", rangeExpr, f.name)
+			}
+		}
+
+		for value := lower; value <= upper; value += step {
+			set |= 1 << value
+		}
+	}
+	return set, nil
+}
+
+// parseValue parses a single value and checks that it is within the range of the field
+func parseValue(s string, f field) (int, error) {
+	value, err := strconv.Atoi(s)
+	if err != nil || value < f.min || value > f.max {
+		return 0, fmt.Errorf("invalid %s %q, must be between %d and %d", f.name, s, f.min, f.max)
+	}
+	return value, nil
+}
+
+// String returns the original cron expression
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// Matches checks if the schedule fires at the minute of the given time
+func (s *Schedule) Matches(t time.Time) bool {
+	if s.minute&(1<<t.Minute()) == 0 || s.hour&(1<<t.Hour()) == 0 || s.month&(1<<int(t.Month())) == 0 {
+		return false
+	}
+
+	// like standard cron, a restricted day of month or day of week is enough if both are restricted
+	domMatches := s.dom&(1<<t.Day()) != 0
+	dowMatches := s.dow&(1<<int(t.Weekday())) != 0
+	switch {
+	case s.anyDom && s.anyDow:
+		return true
+	case s.anyDom:
+		return dowMatches
+	case s.anyDow:
+		return domMatches
+	default:
+		return domMatches || dowMatches
+	}
+}
+
+// ActiveWithin checks if the schedule fired within the duration before t, including the minute of t
+func (s *Schedule) ActiveWithin(t time.Time, duration time.Duration) bool {
+	current := t.Truncate(time.Minute)
+	earliest := t.Add(-duration)
+	for ; current.After(earliest); current = current.Add(-time.Minute) {
+		if s.Matches(current) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse_Matches(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		matching []time.Time
+		rejected []time.Time
+	}{
+		{
+			name:     "Every Sunday at 02:00",
+			expr:     "0 2 * * 0",
+			matching: []time.Time{time.Date(2025, 1, 5, 2, 0, 0, 0, time.UTC)},
+			rejected: []time.Time{time.Date(2025, 1, 5, 2, 1, 0, 0, time.UTC), time.Date(2025, 1, 6, 2, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "Sunday as 7",
+			expr:     "0 2 * * 7",
+			matching: []time.Time{time.Date(2025, 1, 5, 2, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "Steps and ranges",
+			expr:     "*/15 9-17 * * 1-5",
+			matching: []time.Time{time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC), time.Date(2025, 1, 10, 17, 45, 0, 0, time.UTC)},
+			rejected: []time.Time{time.Date(2025, 1, 6, 9, 10, 0, 0, time.UTC), time.Date(2025, 1, 6, 18, 0, 0, 0, time.UTC), time.Date(2025, 1, 11, 9, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "Lists",
+			expr:     "30 1,13 1 * *",
+			matching: []time.Time{time.Date(2025, 3, 1, 1, 30, 0, 0, time.UTC), time.Date(2025, 3, 1, 13, 30, 0, 0, time.UTC)},
+			rejected: []time.Time{time.Date(2025, 3, 2, 1, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:     "Day of month or day of week",
+			expr:     "0 0 15 * 1",
+			matching: []time.Time{time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)},
+			rejected: []time.Time{time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			for _, m := range tt.matching {
+				if !schedule.Matches(m) {
+					t.Errorf("Expected %s to match %s", tt.expr, m)
+				}
+			}
+			for _, r := range tt.rejected {
+				if schedule.Matches(r) {
+					t.Errorf("Expected %s not to match %s", tt.expr, r)
+				}
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "5-1 * * * *", "*/0 * * * *", "a * * * *"} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Expected %q to be rejected", expr)
+		}
+	}
+}
+
+func TestSchedule_ActiveWithin(t *testing.T) {
+	schedule, err := Parse("0 2 * * 0")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	tests := []struct {
+		time     time.Time
+		expected bool
+	}{
+		{time.Date(2025, 1, 5, 1, 59, 0, 0, time.UTC), false},
+		{time.Date(2025, 1, 5, 2, 0, 0, 0, time.UTC), true},
+		{time.Date(2025, 1, 5, 2, 59, 59, 0, time.UTC), true},
+		{time.Date(2025, 1, 5, 3, 0, 0, 0, time.UTC), false},
+		{time.Date(2025, 1, 6, 2, 30, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		if active := schedule.ActiveWithin(tt.time, time.Hour); active != tt.expected {
+			t.Errorf("Expected ActiveWithin(%s) to be %v, got %v", tt.time, tt.expected, active)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Assertion is a compiled assertion such as `$.status == "ok"`, `$.checks[*].healthy all true`
+// or `$.version matches ^2\.`
+type Assertion struct {
+	expr       string
+	path       *Path
+	quantifier string
+	operator   string
+	expected   any
+	regex      *regexp.Regexp
+}
+
+// operators lists the supported comparison operators
+var operators = []string{"==", "!=", ">=", "<=", ">", "<", "matches", "exists", "not_exists"}
+
+// ParseAssertion compiles an assertion of the form `<path> [all|any] [operator] [value]`.
+// Without an operator the value is compared with `==`, without a quantifier every selected value must match.
+func ParseAssertion(expr string) (*Assertion, error) {
+	expr = strings.TrimSpace(expr)
+	pathExpr, rest := splitToken(expr)
+	path, err := ParsePath(pathExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	assertion := &Assertion{expr: expr, path: path, quantifier: "all", operator: "=="}
+
+	if token, remaining := splitToken(rest); token == "all" || token == "any" {
+		assertion.quantifier = token
+		rest = remaining
+	}
+
+	token, remaining := splitToken(rest)
+	for _, op := range operators {
+		if token == op {
+			assertion.operator = op
+			rest = remaining
+			break
+		}
+	}
+
+	switch assertion.operator {
+	case "exists", "not_exists":
+		if rest != "" {
+			return nil, fmt.Errorf("assertion %q: %s takes no value", expr, assertion.operator)
+		}
+	case "matches":
+		regex, err := regexp.Compile(rest)
+		if err != nil {
+			return nil, fmt.Errorf("assertion %q: invalid regex: %w", expr, err)
+		}
+		assertion.regex = regex
+	default:
+		if rest == "" {
+			return nil, fmt.Errorf("assertion %q: missing expected value", expr)
+		}
+		if err := json.Unmarshal([]byte(rest), &assertion.expected); err != nil {
+			return nil, fmt.Errorf("assertion %q: expected value must be a JSON literal: %w", expr, err)
+		}
+		if _, isNumber := assertion.expected.(float64); !isNumber && assertion.operator != "==" && assertion.operator != "!=" {
+			return nil, fmt.Errorf("assertion %q: %s needs a number", expr, assertion.operator)
+		}
+	}
+
+	return assertion, nil
+}
+
+// String returns the original assertion expression
+func (a *Assertion) String() string {
+	return a.expr
+}
+
+// Evaluate checks the assertion against a decoded JSON document.
+// It returns whether the assertion holds and the actual values for failure messages.
+func (a *Assertion) Evaluate(doc any) (bool, string) {
+	values := a.path.Select(doc)
+	actual := formatValues(values, a.path.HasWildcard())
+
+	switch a.operator {
+	case "exists":
+		return len(values) > 0, actual
+	case "not_exists":
+		return len(values) == 0, actual
+	}
+
+	if len(values) == 0 {
+		return false, actual
+	}
+
+	for _, value := range values {
+		matched := a.match(value)
+		if a.quantifier == "any" && matched {
+			return true, actual
+		}
+		if a.quantifier == "all" && !matched {
+			return false, actual
+		}
+	}
+	return a.quantifier == "all", actual
+}
+
+// match checks a single selected value
+func (a *Assertion) match(value any) bool {
+	switch a.operator {
+	case "==":
+		return reflect.DeepEqual(value, a.expected)
+	case "!=":
+		return !reflect.DeepEqual(value, a.expected)
+	case "matches":
+		return a.regex.MatchString(formatValue(value))
+	}
+
+	number, ok := value.(float64)
+	if !ok {
+		return false
+	}
+	expected := a.expected.(float64)
+	switch a.operator {
+	case ">":
+		return number > expected
+	case ">=":
+		return number >= expected
+	case "<":
+		return number < expected
+	case "<=":
+		return number <= expected
+	}
+	return false
+}
+
+// splitToken splits off the first whitespace separated token
+func splitToken(s string) (string, string) {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexAny(s, " \t"); i >= 0 {
+		return s[:i], strings.TrimSpace(s[i+1:])
+	}
+	return s, ""
+}
+
+// formatValue converts a JSON value to the string used for regex matching, strings are not quoted
+func formatValue(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
+
+// formatValues converts the selected values to JSON for failure messages
+func formatValues(values []any, asList bool) string {
+	if len(values) == 0 {
+		return "<missing>"
+	}
+	var b []byte
+	var err error
+	if asList {
+		b, err = json.Marshal(values)
+	} else {
+		b, err = json.Marshal(values[0])
+	}
+	if err != nil {
+		return fmt.Sprint(values)
+	}
+	return string(b)
+}
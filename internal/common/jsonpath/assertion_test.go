@@ -0,0 +1,134 @@
+package jsonpath
+
+import (
+	"strings"
+	"testing"
+)
+
+const healthBody = `{
+	"status": "ok",
+	"version": "2.4.1",
+	"uptime": 3600,
+	"checks": [
+		{"name": "db", "healthy": true, "latency": 12},
+		{"name": "cache", "healthy": false, "latency": 250}
+	],
+	"meta": {"region": "eu-west-1", "build id": "abc"}
+}`
+
+func TestParsePath(t *testing.T) {
+	valid := []string{"$", "$.status", "$.checks[0].name", "$.checks[*].healthy", "$['meta']['build id']", "$.meta.*", "$.checks[-1]"}
+	for _, expr := range valid {
+		if _, err := ParsePath(expr); err != nil {
+			t.Errorf("ParsePath(%q) returned error: %v", expr, err)
+		}
+	}
+
+	invalid := []string{"status", "$.", "$[abc]", "$['unterminated", "$..status"}
+	for _, expr := range invalid {
+		if _, err := ParsePath(expr); err == nil {
+			t.Errorf("ParsePath(%q) should return an error", expr)
+		}
+	}
+}
+
+func TestPath_Select(t *testing.T) {
+	doc, err := ParseDocument([]byte(healthBody))
+	if err != nil {
+		t.Fatalf("ParseDocument failed: %v", err)
+	}
+
+	tests := []struct {
+		expr     string
+		expected int
+	}{
+		{"$.status", 1},
+		{"$.checks[*].name", 2},
+		{"$.checks[1].latency", 1},
+		{"$.checks[-1].name", 1},
+		{"$.checks[5].name", 0},
+		{"$['meta']['build id']", 1},
+		{"$.meta.*", 2},
+		{"$.missing", 0},
+	}
+
+	for _, tt := range tests {
+		path, err := ParsePath(tt.expr)
+		if err != nil {
+			t.Fatalf("ParsePath(%q) failed: %v", tt.expr, err)
+		}
+		if got := len(path.Select(doc)); got != tt.expected {
+			t.Errorf("Select(%q) returned %d values, want %d", tt.expr, got, tt.expected)
+		}
+	}
+}
+
+func TestAssertion_Evaluate(t *testing.T) {
+	doc, err := ParseDocument([]byte(healthBody))
+	if err != nil {
+		t.Fatalf("ParseDocument failed: %v", err)
+	}
+
+	tests := []struct {
+		expr     string
+		expected bool
+		actual   string
+	}{
+		{`$.status == "ok"`, true, `"ok"`},
+		{`$.status "ok"`, true, `"ok"`},
+		{`$.status != "down"`, true, `"ok"`},
+		{`$.status == "degraded"`, false, `"ok"`},
+		{`$.version matches ^2\.`, true, `"2.4.1"`},
+		{`$.version matches ^3\.`, false, `"2.4.1"`},
+		{`$.uptime >= 60`, true, `3600`},
+		{`$.uptime < 60`, false, `3600`},
+		{`$.checks[*].healthy all true`, false, `[true,false]`},
+		{`$.checks[*].healthy any true`, true, `[true,false]`},
+		{`$.checks[*].latency all < 500`, true, `[12,250]`},
+		{`$.checks[0].name == "db"`, true, `"db"`},
+		{`$.meta.region exists`, true, `"eu-west-1"`},
+		{`$.error not_exists`, true, `<missing>`},
+		{`$.missing == 1`, false, `<missing>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			assertion, err := ParseAssertion(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseAssertion failed: %v", err)
+			}
+			ok, actual := assertion.Evaluate(doc)
+			if ok != tt.expected {
+				t.Errorf("Evaluate() = %v, want %v", ok, tt.expected)
+			}
+			if actual != tt.actual {
+				t.Errorf("Evaluate() actual = %s, want %s", actual, tt.actual)
+			}
+		})
+	}
+}
+
+func TestParseAssertion_Invalid(t *testing.T) {
+	tests := []struct {
+		expr    string
+		message string
+	}{
+		{`status == "ok"`, "must start with $"},
+		{`$.status ==`, "missing expected value"},
+		{`$.status == ok`, "JSON literal"},
+		{`$.uptime > "fast"`, "needs a number"},
+		{`$.version matches (`, "invalid regex"},
+		{`$.status exists true`, "takes no value"},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseAssertion(tt.expr)
+		if err == nil {
+			t.Errorf("ParseAssertion(%q) should return an error", tt.expr)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.message) {
+			t.Errorf("ParseAssertion(%q) error = %v, want it to contain %q", tt.expr, err, tt.message)
+		}
+	}
+}
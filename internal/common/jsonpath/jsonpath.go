@@ -0,0 +1,135 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// segment is a single step of a JSON path, either a key, an index or a wildcard
+type segment struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// Path is a compiled JSON path supporting `$`, `.key`, `['key']`, `[n]` and `[*]`
+type Path struct {
+	expr     string
+	segments []segment
+}
+
+// ParsePath compiles a JSON path expression such as `$.checks[*].healthy`
+func ParsePath(expr string) (*Path, error) {
+	if !strings.HasPrefix(expr, "$") {
+		return nil, fmt.Errorf("path %q must start with $", expr)
+	}
+
+	path := &Path{expr: expr}
+	rest := expr[1:]
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, "[*]"):
+			path.segments = append(path.segments, segment{wildcard: true})
+			rest = rest[3:]
+		case strings.HasPrefix(rest, "['"):
+			end := strings.Index(rest, "']")
+			if end < 0 {
+				return nil, fmt.Errorf("path %q has an unterminated ['key']", expr)
+			}
+			path.segments = append(path.segments, segment{key: rest[2:end]})
+			rest = rest[end+2:]
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("path %q has an unterminated [index]", expr)
+			}
+			index, err := strconv.Atoi(rest[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("path %q has an invalid index %q", expr, rest[1:end])
+			}
+			path.segments = append(path.segments, segment{index: index, isIndex: true})
+			rest = rest[end+1:]
+		case strings.HasPrefix(rest, "."):
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			key := rest[1 : end+1]
+			if key == "" {
+				return nil, fmt.Errorf("path %q has an empty key", expr)
+			}
+			if key == "*" {
+				path.segments = append(path.segments, segment{wildcard: true})
+			} else {
+				path.segments = append(path.segments, segment{key: key})
+			}
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("path %q is invalid near %q", expr, rest)
+		}
+	}
+
+	return path, nil
+}
+
+// String returns the original path expression
+func (p *Path) String() string {
+	return p.expr
+}
+
+// HasWildcard checks if the path can select more than one value
+func (p *Path) HasWildcard() bool {
+	for _, seg := range p.segments {
+		if seg.wildcard {
+			return true
+		}
+	}
+	return false
+}
+
+// Select returns all values in the document selected by the path
+func (p *Path) Select(doc any) []any {
+	current := []any{doc}
+	for _, seg := range p.segments {
+		var next []any
+		for _, value := range current {
+			switch v := value.(type) {
+			case map[string]any:
+				if seg.wildcard {
+					for _, child := range v {
+						next = append(next, child)
+					}
+				} else if child, ok := v[seg.key]; ok && !seg.isIndex {
+					next = append(next, child)
+				}
+			case []any:
+				if seg.wildcard {
+					next = append(next, v...)
+				} else if seg.isIndex {
+					index := seg.index
+					if index < 0 {
+						index += len(v)
+					}
+					if index >= 0 && index < len(v) {
+						next = append(next, v[index])
+					}
+				}
+			}
+		}
+		current = next
+	}
+	return current
+}
+
+// ParseDocument decodes a JSON body into a document that paths can select from
+func ParseDocument(body []byte) (any, error) {
+	var doc any
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, errors.New("response body is not valid JSON")
+	}
+	return doc, nil
+}
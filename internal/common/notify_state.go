@@ -0,0 +1,62 @@
+package common
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+// ReadNotifyState loads the alert state from file or returns an empty state
+func ReadNotifyState(statePath string) (notifier.State, error) {
+	state := make(notifier.State)
+
+	stateContent, err := os.ReadFile(statePath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		return state, nil
+	}
+
+	if err := json.Unmarshal(stateContent, &state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// WriteNotifyState writes the alert state to file
+func WriteNotifyState(state notifier.State, statePath string) error {
+	stateContent, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(statePath, stateContent, 0644)
+}
+
+// ReadChannelStates loads the state of the notification methods from file or returns an empty state
+func ReadChannelStates(statePath string) (notifier.ChannelStates, error) {
+	states := make(notifier.ChannelStates)
+
+	stateContent, err := os.ReadFile(statePath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		return states, nil
+	}
+
+	if err := json.Unmarshal(stateContent, &states); err != nil {
+		return nil, err
+	}
+	return states, nil
+}
+
+// WriteChannelStates writes the state of the notification methods to file
+func WriteChannelStates(states notifier.ChannelStates, statePath string) error {
+	stateContent, err := json.MarshalIndent(states, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(statePath, stateContent, 0644)
+}
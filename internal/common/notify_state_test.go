@@ -0,0 +1,30 @@
+package common
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+func TestReadWriteNotifyState(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "notify_state.json")
+
+	state, err := ReadNotifyState(statePath)
+	if err != nil || len(state) != 0 {
+		t.Fatalf("Expected an empty state for a missing file, got %v, %v", state, err)
+	}
+
+	state["API"] = notifier.ServiceState{"https://api.example.com": {Status: "down", Since: "2025-01-01T10:00:00Z"}}
+	if err := WriteNotifyState(state, statePath); err != nil {
+		t.Fatalf("WriteNotifyState failed: %v", err)
+	}
+
+	loaded, err := ReadNotifyState(statePath)
+	if err != nil {
+		t.Fatalf("ReadNotifyState failed: %v", err)
+	}
+	if loaded["API"]["https://api.example.com"] != state["API"]["https://api.example.com"] {
+		t.Errorf("Expected %+v, got %+v", state, loaded)
+	}
+}
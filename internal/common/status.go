@@ -8,20 +8,30 @@ func calcMergedStatus(statusList []chk_result.CheckResult) chk_result.CheckResul
 		return chk_result.NONE
 	}
 
-	hasNone, hasAll := false, false
+	hasNone, hasAll, hasDegraded, hasPart := false, false, false, false
 	for _, s := range statusList {
 		switch s {
+		case chk_result.MAINTENANCE:
+			// maintenance windows apply to whole services, so the merged check was in maintenance too
+			return chk_result.MAINTENANCE
 		case chk_result.NONE:
 			hasNone = true
 		case chk_result.ALL:
 			hasAll = true
+		case chk_result.DEGRADED:
+			hasDegraded = true
+		case chk_result.PART:
+			hasPart = true
 		}
 	}
 
+	// a partial outage takes precedence over slow checks
 	switch {
-	case hasNone && !hasAll:
+	case hasNone && !hasAll && !hasDegraded && !hasPart:
 		return chk_result.NONE
-	case !hasNone && hasAll:
+	case !hasNone && !hasPart && hasDegraded:
+		return chk_result.DEGRADED
+	case !hasNone && !hasPart && hasAll:
 		return chk_result.ALL
 	default:
 		return chk_result.PART
@@ -0,0 +1,35 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+)
+
+func TestCalcMergedStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []chk_result.CheckResult
+		expected chk_result.CheckResult
+	}{
+		{name: "Empty", statuses: nil, expected: chk_result.NONE},
+		{name: "All online", statuses: []chk_result.CheckResult{chk_result.ALL, chk_result.ALL}, expected: chk_result.ALL},
+		{name: "All down", statuses: []chk_result.CheckResult{chk_result.NONE, chk_result.NONE}, expected: chk_result.NONE},
+		{name: "Online and down", statuses: []chk_result.CheckResult{chk_result.ALL, chk_result.NONE}, expected: chk_result.PART},
+		{name: "Online and degraded", statuses: []chk_result.CheckResult{chk_result.ALL, chk_result.DEGRADED}, expected: chk_result.DEGRADED},
+		{name: "Degraded only", statuses: []chk_result.CheckResult{chk_result.DEGRADED}, expected: chk_result.DEGRADED},
+		{name: "Degraded and down", statuses: []chk_result.CheckResult{chk_result.DEGRADED, chk_result.NONE}, expected: chk_result.PART},
+		{name: "Degraded and partial", statuses: []chk_result.CheckResult{chk_result.DEGRADED, chk_result.PART}, expected: chk_result.PART},
+		{name: "Online and partial", statuses: []chk_result.CheckResult{chk_result.ALL, chk_result.PART}, expected: chk_result.PART},
+		{name: "Down and partial", statuses: []chk_result.CheckResult{chk_result.NONE, chk_result.PART}, expected: chk_result.PART},
+		{name: "Maintenance", statuses: []chk_result.CheckResult{chk_result.MAINTENANCE, chk_result.MAINTENANCE}, expected: chk_result.MAINTENANCE},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := calcMergedStatus(tt.statuses); result != tt.expected {
+				t.Errorf("Expected status %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
@@ -1,12 +1,24 @@
 package configure
 
 import (
+	"fmt"
 	"log"
+	"net"
 	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/wcy-dt/ponghub/internal/common/cron"
+	"github.com/wcy-dt/ponghub/internal/common/jsonpath"
 	"github.com/wcy-dt/ponghub/internal/common/params"
 	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
 	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
+	"github.com/wcy-dt/ponghub/internal/types/types/endpoint_type"
+	"github.com/wcy-dt/ponghub/internal/types/types/http_method"
+	"github.com/wcy-dt/ponghub/internal/types/types/severity"
+	"github.com/wcy-dt/ponghub/internal/types/types/smtp_auth"
 
 	"gopkg.in/yaml.v3"
 )
@@ -37,6 +49,31 @@ func ReadConfigs(path string) (*configure.Configure, error) {
 	// Set default values for the configuration
 	setDefaultConfigs(cfg)
 
+	// Parse check intervals used by the daemon mode
+	if err := parseIntervals(cfg); err != nil {
+		return nil, err
+	}
+
+	// Parse the maintenance windows, services inherit the global ones
+	if err := parseMaintenanceWindows(cfg); err != nil {
+		return nil, err
+	}
+
+	// Validate the dependencies between services
+	if err := validateDependencies(cfg); err != nil {
+		return nil, err
+	}
+
+	// Validate the endpoint definitions
+	if err := validateEndpoints(cfg); err != nil {
+		return nil, err
+	}
+
+	// Validate the notification channels
+	if err := validateNotifications(cfg); err != nil {
+		return nil, err
+	}
+
 	if len(cfg.Services) == 0 {
 		log.Fatalln("No services defined in the configuration file")
 	}
@@ -55,6 +92,8 @@ func resolveConfigParameters(cfg *configure.Configure) {
 			endpoint.ParsedURL = resolver.ResolveParameters(endpoint.URL)
 			endpoint.ParsedBody = resolver.ResolveParameters(endpoint.Body)
 			endpoint.ParsedResponseRegex = resolver.ResolveParameters(endpoint.ResponseRegex)
+			endpoint.ParsedSend = resolver.ResolveParameters(endpoint.Send)
+			endpoint.ParsedExpect = resolver.ResolveParameters(endpoint.Expect)
 			if endpoint.Headers != nil {
 				endpoint.ParsedHeaders = make(map[string]string)
 				for key, value := range endpoint.Headers {
@@ -72,16 +111,567 @@ func setDefaultConfigs(cfg *configure.Configure) {
 	default_config.SetDefaultMaxLogDays(&cfg.MaxLogDays)
 	default_config.SetDefaultCertNotifyDays(&cfg.CertNotifyDays)
 	default_config.SetDefaultDisplayNum(&cfg.DisplayNum)
+	default_config.SetDefaultConcurrency(&cfg.Concurrency)
+	default_config.SetDefaultInterval(&cfg.Interval)
+	default_config.SetDefaultAlertAfterFailures(&cfg.AlertAfterFailures)
+	default_config.SetDefaultRecoverAfterSuccesses(&cfg.RecoverAfterSuccesses)
 
 	for i := range cfg.Services {
 		default_config.SetDefaultTimeout(&cfg.Services[i].Timeout)
 		default_config.SetDefaultMaxRetryTimes(&cfg.Services[i].MaxRetryTimes)
+
+		// services inherit the global concurrency unless they set their own
+		if cfg.Services[i].Concurrency <= 0 {
+			cfg.Services[i].Concurrency = cfg.Concurrency
+		}
+		if cfg.Services[i].Interval == "" {
+			cfg.Services[i].Interval = cfg.Interval
+		}
+
+		// alert thresholds are inherited from the global config by services, and from services by endpoints
+		service := &cfg.Services[i]
+		if service.AlertAfterFailures <= 0 {
+			service.AlertAfterFailures = cfg.AlertAfterFailures
+		}
+		if service.RecoverAfterSuccesses <= 0 {
+			service.RecoverAfterSuccesses = cfg.RecoverAfterSuccesses
+		}
+		for j := range service.Endpoints {
+			if service.Endpoints[j].AlertAfterFailures <= 0 {
+				service.Endpoints[j].AlertAfterFailures = service.AlertAfterFailures
+			}
+			if service.Endpoints[j].RecoverAfterSuccesses <= 0 {
+				service.Endpoints[j].RecoverAfterSuccesses = service.RecoverAfterSuccesses
+			}
+		}
 	}
 
 	// Set default notification configuration
 	setDefaultNotifications(cfg)
 }
 
+// parseIntervals parses the global and per-service check intervals and the notification reminder interval
+func parseIntervals(cfg *configure.Configure) error {
+	interval, err := parseInterval(cfg.Interval)
+	if err != nil {
+		return err
+	}
+	cfg.ParsedInterval = interval
+
+	// reminders while an endpoint stays down are disabled unless renotify_interval is set
+	if cfg.Notifications != nil && cfg.Notifications.RenotifyInterval != "" {
+		renotifyInterval, err := parseInterval(cfg.Notifications.RenotifyInterval)
+		if err != nil {
+			return fmt.Errorf("notifications: renotify_interval: %w", err)
+		}
+		cfg.Notifications.ParsedRenotifyInterval = renotifyInterval
+	}
+	if err := parseDigests(cfg.Notifications); err != nil {
+		return err
+	}
+
+	for i := range cfg.Services {
+		interval, err := parseInterval(cfg.Services[i].Interval)
+		if err != nil {
+			return fmt.Errorf("service %s: %w", cfg.Services[i].Name, err)
+		}
+		cfg.Services[i].ParsedInterval = interval
+	}
+	return nil
+}
+
+// parseDigests parses the digest intervals and normalizes the methods of the digests
+func parseDigests(config *configure.NotificationConfig) error {
+	if config == nil || len(config.Digests) == 0 {
+		return nil
+	}
+	methods := make(map[string]bool)
+	for _, method := range config.Methods {
+		methods[strings.ToLower(method)] = true
+	}
+
+	digests := make(map[string]configure.DigestConfig, len(config.Digests))
+	for method, digest := range config.Digests {
+		if !methods[strings.ToLower(method)] {
+			return fmt.Errorf("notifications: digest of %q: method is not in the notification methods", method)
+		}
+		// PagerDuty only accepts events, it cannot send the plain text summary of a digest
+		if strings.ToLower(method) == "pagerduty" {
+			return fmt.Errorf("notifications: digest of %q: method only supports event notifications", method)
+		}
+		var err error
+		switch strings.ToLower(digest.Interval) {
+		case "hourly":
+			digest.ParsedInterval = time.Hour
+		case "daily":
+			digest.ParsedInterval = 24 * time.Hour
+		default:
+			if digest.ParsedInterval, err = parseInterval(digest.Interval); err != nil {
+				return fmt.Errorf("notifications: digest of %q: %w", method, err)
+			}
+		}
+		digests[strings.ToLower(method)] = digest
+	}
+	config.Digests = digests
+	return nil
+}
+
+// parseInterval parses a single interval such as "30s" or "5m"
+func parseInterval(s string) (time.Duration, error) {
+	interval, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid interval %q: %w", s, err)
+	}
+	if interval <= 0 {
+		return 0, fmt.Errorf("invalid interval %q: must be positive", s)
+	}
+	return interval, nil
+}
+
+// parseMaintenanceWindows parses the global and per-service maintenance windows and adds the global ones to every service
+func parseMaintenanceWindows(cfg *configure.Configure) error {
+	for i := range cfg.Maintenance {
+		if err := parseMaintenanceWindow(&cfg.Maintenance[i]); err != nil {
+			return fmt.Errorf("maintenance window %d: %w", i+1, err)
+		}
+	}
+
+	for i := range cfg.Services {
+		service := &cfg.Services[i]
+		for j := range service.Maintenance {
+			if err := parseMaintenanceWindow(&service.Maintenance[j]); err != nil {
+				return fmt.Errorf("service %s, maintenance window %d: %w", service.Name, j+1, err)
+			}
+		}
+		service.Maintenance = append(slices.Clone(cfg.Maintenance), service.Maintenance...)
+	}
+	return nil
+}
+
+// parseMaintenanceWindow parses a single maintenance window, which needs either start and end or cron and duration
+func parseMaintenanceWindow(window *configure.MaintenanceWindow) error {
+	location := time.UTC
+	if window.Timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(window.Timezone); err != nil {
+			return fmt.Errorf("invalid timezone %q: %w", window.Timezone, err)
+		}
+	}
+	window.ParsedTimezone = location
+
+	isOneOff := window.Start != "" || window.End != ""
+	isRecurring := window.Cron != "" || window.Duration != ""
+	switch {
+	case isOneOff && isRecurring:
+		return fmt.Errorf("start and end cannot be combined with cron and duration")
+
+	case isOneOff:
+		var err error
+		if window.ParsedStart, err = parseMaintenanceTime("start", window.Start, location); err != nil {
+			return err
+		}
+		if window.ParsedEnd, err = parseMaintenanceTime("end", window.End, location); err != nil {
+			return err
+		}
+		if !window.ParsedEnd.After(window.ParsedStart) {
+			return fmt.Errorf("end %q must be after start %q", window.End, window.Start)
+		}
+
+	case isRecurring:
+		if window.Cron == "" || window.Duration == "" {
+			return fmt.Errorf("recurring windows need both cron and duration")
+		}
+		schedule, err := cron.Parse(window.Cron)
+		if err != nil {
+			return err
+		}
+		window.ParsedCron = schedule
+		if window.ParsedDuration, err = parseInterval(window.Duration); err != nil {
+			return fmt.Errorf("duration: %w", err)
+		}
+
+	default:
+		return fmt.Errorf("either start and end or cron and duration must be set")
+	}
+	return nil
+}
+
+// parseMaintenanceTime parses a maintenance start or end time, written in RFC 3339 or as "2006-01-02 15:04" in the window's timezone
+func parseMaintenanceTime(name, s string, location *time.Location) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, fmt.Errorf("one-off windows need both start and end")
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02 15:04", s, location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s %q: use RFC 3339 or \"2006-01-02 15:04\"", name, s)
+	}
+	return t, nil
+}
+
+// validateDependencies checks that services only depend on other existing services and that there are no cycles
+func validateDependencies(cfg *configure.Configure) error {
+	dependsOn := make(map[string][]string)
+	for _, service := range cfg.Services {
+		dependsOn[service.Name] = service.DependsOn
+	}
+	for _, service := range cfg.Services {
+		for _, parent := range service.DependsOn {
+			if _, exists := dependsOn[parent]; !exists {
+				return fmt.Errorf("service %s: depends on unknown service %q", service.Name, parent)
+			}
+			if parent == service.Name {
+				return fmt.Errorf("service %s: cannot depend on itself", service.Name)
+			}
+		}
+	}
+
+	// depth-first search, a service reached again while it is still on the path closes a cycle
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	marks := make(map[string]int)
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch marks[name] {
+		case visiting:
+			return fmt.Errorf("service %s: dependency cycle %s", name, strings.Join(append(path, name), " -> "))
+		case visited:
+			return nil
+		}
+		marks[name] = visiting
+		for _, parent := range dependsOn[name] {
+			if err := visit(parent, append(path, name)); err != nil {
+				return err
+			}
+		}
+		marks[name] = visited
+		return nil
+	}
+	for _, service := range cfg.Services {
+		if err := visit(service.Name, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateEndpoints checks that every endpoint can be checked
+func validateEndpoints(cfg *configure.Configure) error {
+	resolver := params.NewParameterResolver()
+	for i := range cfg.Services {
+		for j := range cfg.Services[i].Endpoints {
+			endpoint := &cfg.Services[i].Endpoints[j]
+			endpointType := endpoint_type.ParseEndpointType(endpoint.Type)
+			if !endpointType.IsValid() {
+				return fmt.Errorf("service %s, endpoint %s: unsupported type %q", cfg.Services[i].Name, endpoint.URL, endpoint.Type)
+			}
+			endpoint.Type = endpointType.String()
+
+			if endpointType == endpoint_type.TCP {
+				if _, _, err := net.SplitHostPort(endpoint.ParsedURL); err != nil {
+					return fmt.Errorf("service %s, endpoint %s: tcp endpoints need a host:port address: %w", cfg.Services[i].Name, endpoint.URL, err)
+				}
+			}
+			if endpointType == endpoint_type.HTTP {
+				if err := validateHTTPEndpoint(endpoint); err != nil {
+					return fmt.Errorf("service %s, endpoint %s: %w", cfg.Services[i].Name, endpoint.URL, err)
+				}
+			}
+			if endpointType == endpoint_type.DNS {
+				if err := validateDNSEndpoint(endpoint); err != nil {
+					return fmt.Errorf("service %s, endpoint %s: %w", cfg.Services[i].Name, endpoint.URL, err)
+				}
+			}
+			for k := range endpoint.HeaderAssertions {
+				if err := validateHeaderAssertion(&endpoint.HeaderAssertions[k]); err != nil {
+					return fmt.Errorf("service %s, endpoint %s: %w", cfg.Services[i].Name, endpoint.URL, err)
+				}
+			}
+			// the assertions are parsed once here, so the checks only evaluate them
+			endpoint.ParsedJSONAssertions = nil
+			for _, expr := range endpoint.JSONAssertions {
+				assertion, err := jsonpath.ParseAssertion(resolver.ResolveParameters(expr))
+				if err != nil {
+					return fmt.Errorf("service %s, endpoint %s: %w", cfg.Services[i].Name, endpoint.URL, err)
+				}
+				endpoint.ParsedJSONAssertions = append(endpoint.ParsedJSONAssertions, assertion)
+			}
+			if endpoint.ParsedExpect != "" {
+				if _, err := regexp.Compile(endpoint.ParsedExpect); err != nil {
+					return fmt.Errorf("service %s, endpoint %s: invalid expect regex: %w", cfg.Services[i].Name, endpoint.URL, err)
+				}
+			}
+			if err := parseLatencyThresholds(endpoint); err != nil {
+				return fmt.Errorf("service %s, endpoint %s: %w", cfg.Services[i].Name, endpoint.URL, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateNotifications checks the settings of the notification channels that cannot be checked when decoding
+func validateNotifications(cfg *configure.Configure) error {
+	for i := range cfg.Services {
+		level, err := severity.ParseSeverity(cfg.Services[i].Severity)
+		if err != nil {
+			return fmt.Errorf("service %s: %w", cfg.Services[i].Name, err)
+		}
+		cfg.Services[i].Severity = level.String()
+	}
+
+	if cfg.Notifications == nil {
+		return nil
+	}
+	if err := validateRoutes(cfg); err != nil {
+		return err
+	}
+	if err := parseLimits(cfg.Notifications); err != nil {
+		return err
+	}
+	if err := validateTemplates(cfg.Notifications.Templates); err != nil {
+		return err
+	}
+	if email := cfg.Notifications.Email; email != nil {
+		auth, err := smtp_auth.ParseSMTPAuth(email.Auth)
+		if err != nil {
+			return fmt.Errorf("email notification: %w", err)
+		}
+		email.Auth = auth.String()
+	}
+	if webhook := cfg.Notifications.Webhook; webhook != nil && webhook.Signing != nil {
+		switch strings.ToLower(webhook.Signing.Algorithm) {
+		case "", "sha256", "sha512":
+		default:
+			return fmt.Errorf("webhook notification: unsupported signing algorithm %q", webhook.Signing.Algorithm)
+		}
+	}
+	return nil
+}
+
+// sectionMethods are the notification methods that build their messages from the alerts themselves,
+// only the title template applies to them
+var sectionMethods = map[string]bool{
+	"slack": true, "discord": true, "teams": true, "dingtalk": true, "feishu": true, "lark": true, "wecom": true,
+}
+
+// validateTemplates checks that the templates of the notification methods are used by them
+func validateTemplates(templates *configure.TemplatesConfig) error {
+	if templates == nil {
+		return nil
+	}
+	for method, channel := range templates.Channels {
+		switch {
+		case strings.ToLower(method) == "pagerduty" && (channel.Title != "" || channel.Message != ""):
+			return fmt.Errorf("notifications: templates of %q: method sends events and does not use templates", method)
+		case sectionMethods[strings.ToLower(method)] && channel.Message != "":
+			return fmt.Errorf("notifications: templates of %q: method formats its own message, only the title template is used", method)
+		}
+	}
+	return nil
+}
+
+// validateRoutes checks that the notification routes name known services and configured methods
+func validateRoutes(cfg *configure.Configure) error {
+	services := make(map[string]bool)
+	for _, service := range cfg.Services {
+		services[service.Name] = true
+	}
+	methods := make(map[string]bool)
+	for _, method := range cfg.Notifications.Methods {
+		methods[strings.ToLower(method)] = true
+	}
+
+	for i := range cfg.Notifications.Routes {
+		route := &cfg.Notifications.Routes[i]
+		if len(route.Methods) == 0 {
+			return fmt.Errorf("notification route %d: no methods", i+1)
+		}
+		for _, method := range route.Methods {
+			if !methods[strings.ToLower(method)] {
+				return fmt.Errorf("notification route %d: method %q is not in the notification methods", i+1, method)
+			}
+		}
+		for _, name := range route.Services {
+			if !services[name] {
+				return fmt.Errorf("notification route %d: unknown service %q", i+1, name)
+			}
+		}
+		minSeverity, err := severity.ParseSeverity(route.MinSeverity)
+		if err != nil {
+			return fmt.Errorf("notification route %d: %w", i+1, err)
+		}
+		route.MinSeverity = minSeverity.String()
+	}
+	return nil
+}
+
+// parseLimits parses the quiet hours and rate limits of the notification methods and normalizes their methods
+func parseLimits(config *configure.NotificationConfig) error {
+	if len(config.Limits) == 0 {
+		return nil
+	}
+	methods := make(map[string]bool)
+	for _, method := range config.Methods {
+		methods[strings.ToLower(method)] = true
+	}
+
+	limits := make(map[string]configure.LimitsConfig, len(config.Limits))
+	for method, limit := range config.Limits {
+		if !methods[strings.ToLower(method)] {
+			return fmt.Errorf("notifications: limits of %q: method is not in the notification methods", method)
+		}
+		if limit.MaxPerHour < 0 {
+			return fmt.Errorf("notifications: limits of %q: max_per_hour must not be negative", method)
+		}
+		bypassSeverity, err := severity.ParseSeverity(limit.BypassSeverity)
+		if err != nil {
+			return fmt.Errorf("notifications: limits of %q: bypass_severity: %w", method, err)
+		}
+		limit.BypassSeverity = bypassSeverity.String()
+		if limit.QuietHours != nil {
+			if err := parseQuietHours(limit.QuietHours); err != nil {
+				return fmt.Errorf("notifications: limits of %q: quiet_hours: %w", method, err)
+			}
+		}
+		limits[strings.ToLower(method)] = limit
+	}
+	config.Limits = limits
+	return nil
+}
+
+// parseQuietHours parses the times of day and the timezone of quiet hours
+func parseQuietHours(quietHours *configure.QuietHoursConfig) error {
+	location := time.UTC
+	if quietHours.Timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(quietHours.Timezone); err != nil {
+			return fmt.Errorf("invalid timezone %q: %w", quietHours.Timezone, err)
+		}
+	}
+	quietHours.ParsedTimezone = location
+
+	var err error
+	if quietHours.ParsedStart, err = parseTimeOfDay("start", quietHours.Start); err != nil {
+		return err
+	}
+	if quietHours.ParsedEnd, err = parseTimeOfDay("end", quietHours.End); err != nil {
+		return err
+	}
+	if quietHours.ParsedStart == quietHours.ParsedEnd {
+		return fmt.Errorf("start and end cannot be the same time")
+	}
+	return nil
+}
+
+// parseTimeOfDay parses a time of day such as "22:00" into the duration since midnight
+func parseTimeOfDay(field, s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: expected HH:MM", field, s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// validateHTTPEndpoint checks and normalizes the method of an HTTP endpoint
+func validateHTTPEndpoint(endpoint *configure.Endpoint) error {
+	method, err := http_method.ParseHTTPMethod(endpoint.Method)
+	if err != nil {
+		return err
+	}
+	endpoint.Method = method
+
+	if !http_method.HasResponseBody(method) && (endpoint.ResponseRegex != "" || len(endpoint.JSONAssertions) > 0) {
+		return fmt.Errorf("%s responses have no body, response_regex and json_assertions cannot be used", method)
+	}
+	return nil
+}
+
+// validateHeaderAssertion checks that a header assertion names a header and sets exactly one check,
+// and compiles its regex
+func validateHeaderAssertion(assertion *configure.HeaderAssertion) error {
+	if assertion.Name == "" {
+		return fmt.Errorf("header assertion without a name")
+	}
+
+	checkNum := 0
+	for _, isSet := range []bool{assertion.Equals != "", assertion.Regex != "", assertion.NotRegex != "", assertion.Present, assertion.Absent} {
+		if isSet {
+			checkNum++
+		}
+	}
+	if checkNum != 1 {
+		return fmt.Errorf("header assertion for %s must set exactly one of equals, regex, not_regex, present or absent", assertion.Name)
+	}
+
+	for _, pattern := range []string{assertion.Regex, assertion.NotRegex} {
+		if pattern == "" {
+			continue
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("header assertion for %s has an invalid regex: %w", assertion.Name, err)
+		}
+		assertion.ParsedRegex = re
+	}
+	return nil
+}
+
+// validateDNSEndpoint checks the record type and normalizes the resolver address of a DNS endpoint
+func validateDNSEndpoint(endpoint *configure.Endpoint) error {
+	if endpoint.RecordType == "" {
+		endpoint.RecordType = "A"
+	}
+	endpoint.RecordType = strings.ToUpper(endpoint.RecordType)
+	switch endpoint.RecordType {
+	case "A", "AAAA", "CNAME", "MX", "TXT":
+	default:
+		return fmt.Errorf("unsupported DNS record type %q", endpoint.RecordType)
+	}
+
+	// the resolver port defaults to 53
+	if endpoint.Resolver != "" {
+		if _, _, err := net.SplitHostPort(endpoint.Resolver); err != nil {
+			endpoint.Resolver = net.JoinHostPort(endpoint.Resolver, "53")
+		}
+	}
+	return nil
+}
+
+// parseLatencyThresholds parses the warn_latency and max_latency thresholds of an endpoint
+func parseLatencyThresholds(endpoint *configure.Endpoint) error {
+	var err error
+	if endpoint.ParsedWarnLatency, err = parseLatency("warn_latency", endpoint.WarnLatency); err != nil {
+		return err
+	}
+	if endpoint.ParsedMaxLatency, err = parseLatency("max_latency", endpoint.MaxLatency); err != nil {
+		return err
+	}
+	if endpoint.ParsedWarnLatency > 0 && endpoint.ParsedMaxLatency > 0 && endpoint.ParsedWarnLatency >= endpoint.ParsedMaxLatency {
+		return fmt.Errorf("warn_latency %s must be lower than max_latency %s", endpoint.WarnLatency, endpoint.MaxLatency)
+	}
+	return nil
+}
+
+// parseLatency parses a single latency threshold such as "800ms" or "2s", an empty value disables the threshold
+func parseLatency(name, s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	latency, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", name, s, err)
+	}
+	if latency <= 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be positive", name, s)
+	}
+	return latency, nil
+}
+
 // setDefaultNotifications sets default values for notification configuration
 func setDefaultNotifications(cfg *configure.Configure) {
 	if cfg.Notifications == nil {
@@ -97,9 +687,10 @@ func setDefaultNotifications(cfg *configure.Configure) {
 	// Check if other notification methods are configured
 	hasOtherMethods := false
 	for _, method := range cfg.Notifications.Methods {
-		if method == "email" || method == "webhook" {
+		switch method {
+		case "email", "webhook", "slack", "discord", "teams", "telegram", "pagerduty",
+			"dingtalk", "feishu", "lark", "wecom", "ntfy", "gotify":
 			hasOtherMethods = true
-			break
 		}
 	}
 
@@ -0,0 +1,558 @@
+package configure
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfig writes the YAML content to a temporary config file and returns its path
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestReadConfigs_Methods(t *testing.T) {
+	cfg, err := ReadConfigs(writeConfig(t, `
+services:
+  - name: "Methods"
+    endpoints:
+      - url: "https://example.com/a"
+      - url: "https://example.com/b"
+        method: "head"
+      - url: "https://example.com/c"
+        method: "Options"
+`))
+	if err != nil {
+		t.Fatalf("ReadConfigs failed: %v", err)
+	}
+
+	expected := []string{"GET", "HEAD", "OPTIONS"}
+	for i, method := range expected {
+		if got := cfg.Services[0].Endpoints[i].Method; got != method {
+			t.Errorf("Expected endpoint %d to use %s, got %s", i, method, got)
+		}
+	}
+}
+
+func TestReadConfigs_AlertThresholds(t *testing.T) {
+	cfg, err := ReadConfigs(writeConfig(t, `
+alert_after_failures: 3
+services:
+  - name: "Inherited"
+    endpoints:
+      - url: "https://example.com/a"
+  - name: "Service"
+    recover_after_successes: 2
+    endpoints:
+      - url: "https://example.com/b"
+      - url: "https://example.com/c"
+        alert_after_failures: 5
+`))
+	if err != nil {
+		t.Fatalf("ReadConfigs failed: %v", err)
+	}
+
+	expected := []struct {
+		service, endpoint        int
+		alertAfter, recoverAfter int
+	}{
+		{0, 0, 3, 1},
+		{1, 0, 3, 2},
+		{1, 1, 5, 2},
+	}
+	for _, e := range expected {
+		endpoint := cfg.Services[e.service].Endpoints[e.endpoint]
+		if endpoint.AlertAfterFailures != e.alertAfter || endpoint.RecoverAfterSuccesses != e.recoverAfter {
+			t.Errorf("Expected %s to use %d/%d, got %d/%d", endpoint.URL, e.alertAfter, e.recoverAfter,
+				endpoint.AlertAfterFailures, endpoint.RecoverAfterSuccesses)
+		}
+	}
+}
+
+func TestReadConfigs_Maintenance(t *testing.T) {
+	cfg, err := ReadConfigs(writeConfig(t, `
+maintenance:
+  - name: "Weekly deploy"
+    cron: "0 2 * * 0"
+    duration: "1h"
+services:
+  - name: "Inherited"
+    endpoints:
+      - url: "https://example.com/a"
+  - name: "Migration"
+    maintenance:
+      - start: "2025-01-10 22:00"
+        end: "2025-01-10 23:30"
+        timezone: "Asia/Shanghai"
+    endpoints:
+      - url: "https://example.com/b"
+`))
+	if err != nil {
+		t.Fatalf("ReadConfigs failed: %v", err)
+	}
+
+	if len(cfg.Services[0].Maintenance) != 1 || len(cfg.Services[1].Maintenance) != 2 {
+		t.Fatalf("Expected the global window to be added to every service, got %+v", cfg.Services)
+	}
+
+	sunday := time.Date(2025, 1, 5, 2, 30, 0, 0, time.UTC)
+	if !cfg.Services[0].Maintenance.IsActive(sunday) || !cfg.Services[1].Maintenance.IsActive(sunday) {
+		t.Error("Expected the weekly window to be open on Sunday at 02:30 UTC")
+	}
+	migration := time.Date(2025, 1, 10, 14, 30, 0, 0, time.UTC)
+	if cfg.Services[0].Maintenance.IsActive(migration) || !cfg.Services[1].Maintenance.IsActive(migration) {
+		t.Error("Expected the one-off window to be open for its own service only")
+	}
+	if cfg.Services[1].Maintenance.IsActive(migration.Add(time.Hour)) {
+		t.Error("Expected the one-off window to be closed after its end")
+	}
+}
+
+func TestReadConfigs_Limits(t *testing.T) {
+	cfg, err := ReadConfigs(writeConfig(t, `
+notifications:
+  enabled: true
+  methods: ["Email"]
+  limits:
+    Email:
+      quiet_hours:
+        start: "22:00"
+        end: "07:30"
+        timezone: "Asia/Shanghai"
+      max_per_hour: 5
+      bypass_severity: "Critical"
+services:
+  - name: "Limits"
+    endpoints:
+      - url: "https://example.com"
+`))
+	if err != nil {
+		t.Fatalf("ReadConfigs failed: %v", err)
+	}
+
+	limits, ok := cfg.Notifications.Limits["email"]
+	if !ok || limits.MaxPerHour != 5 || limits.BypassSeverity != "critical" {
+		t.Fatalf("Expected the limits of the email method to be normalized, got %+v", cfg.Notifications.Limits)
+	}
+	quietHours := limits.QuietHours
+	for _, tt := range []struct {
+		time   time.Time
+		active bool
+	}{
+		{time.Date(2025, 1, 10, 13, 59, 0, 0, time.UTC), false}, // 21:59 in Shanghai
+		{time.Date(2025, 1, 10, 14, 0, 0, 0, time.UTC), true},   // 22:00
+		{time.Date(2025, 1, 10, 23, 29, 0, 0, time.UTC), true},  // 07:29 the next day
+		{time.Date(2025, 1, 10, 23, 30, 0, 0, time.UTC), false}, // 07:30
+	} {
+		if quietHours.IsActive(tt.time) != tt.active {
+			t.Errorf("Expected the quiet hours to be active=%v at %s", tt.active, tt.time)
+		}
+	}
+}
+
+func TestReadConfigs_HeaderAssertions(t *testing.T) {
+	cfg, err := ReadConfigs(writeConfig(t, `
+services:
+  - name: "Headers"
+    endpoints:
+      - url: "https://example.com"
+        header_assertions:
+          - name: "Cache-Control"
+            regex: "max-age=\\d+"
+          - name: "Server"
+            not_regex: "/[0-9.]+"
+          - name: "X-Powered-By"
+            absent: true
+`))
+	if err != nil {
+		t.Fatalf("ReadConfigs failed: %v", err)
+	}
+
+	assertions := cfg.Services[0].Endpoints[0].HeaderAssertions
+	if assertions[0].ParsedRegex == nil || !assertions[0].ParsedRegex.MatchString("max-age=60") {
+		t.Errorf("Expected the regex to be compiled, got %v", assertions[0].ParsedRegex)
+	}
+	if assertions[1].ParsedRegex == nil || assertions[1].ParsedRegex.String() != "/[0-9.]+" {
+		t.Errorf("Expected the not_regex to be compiled, got %v", assertions[1].ParsedRegex)
+	}
+	if assertions[2].ParsedRegex != nil {
+		t.Errorf("Expected no regex for the absent assertion, got %v", assertions[2].ParsedRegex)
+	}
+}
+
+func TestReadConfigs_JSONAssertions(t *testing.T) {
+	cfg, err := ReadConfigs(writeConfig(t, `
+services:
+  - name: "JSON"
+    endpoints:
+      - url: "https://example.com/health"
+        json_assertions:
+          - '$.status == "ok"'
+          - '$.checks[*].healthy all true'
+`))
+	if err != nil {
+		t.Fatalf("ReadConfigs failed: %v", err)
+	}
+
+	assertions := cfg.Services[0].Endpoints[0].ParsedJSONAssertions
+	if len(assertions) != 2 {
+		t.Fatalf("Expected 2 parsed assertions, got %d", len(assertions))
+	}
+	if assertions[0].String() != `$.status == "ok"` {
+		t.Errorf("Expected the first assertion to be parsed, got %s", assertions[0])
+	}
+}
+
+func TestReadConfigs_InvalidEndpoints(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		message string
+	}{
+		{
+			name: "Unknown method",
+			config: `
+services:
+  - name: "Typo"
+    endpoints:
+      - url: "https://example.com"
+        method: "GTE"
+`,
+			message: `unsupported HTTP method "GTE"`,
+		},
+		{
+			name: "HEAD with response regex",
+			config: `
+services:
+  - name: "Head"
+    endpoints:
+      - url: "https://example.com"
+        method: "HEAD"
+        response_regex: "ok"
+`,
+			message: "HEAD responses have no body",
+		},
+		{
+			name: "Unknown endpoint type",
+			config: `
+services:
+  - name: "Type"
+    endpoints:
+      - url: "example.com:22"
+        type: "udp"
+`,
+			message: `unsupported type "udp"`,
+		},
+		{
+			name: "Invalid latency",
+			config: `
+services:
+  - name: "Latency"
+    endpoints:
+      - url: "https://example.com"
+        warn_latency: "fast"
+`,
+			message: `invalid warn_latency "fast"`,
+		},
+		{
+			name: "Warn latency above max latency",
+			config: `
+services:
+  - name: "Latency"
+    endpoints:
+      - url: "https://example.com"
+        warn_latency: "2s"
+        max_latency: "1s"
+`,
+			message: "must be lower than max_latency",
+		},
+		{
+			name: "Invalid interval",
+			config: `
+interval: "soon"
+services:
+  - name: "Interval"
+    endpoints:
+      - url: "https://example.com"
+`,
+			message: "invalid interval",
+		},
+		{
+			name: "Unknown dependency",
+			config: `
+services:
+  - name: "Billing"
+    depends_on: ["Gatway"]
+    endpoints:
+      - url: "https://example.com"
+`,
+			message: `service Billing: depends on unknown service "Gatway"`,
+		},
+		{
+			name: "Dependency cycle",
+			config: `
+services:
+  - name: "Gateway"
+    depends_on: ["Auth"]
+    endpoints:
+      - url: "https://example.com/gateway"
+  - name: "Auth"
+    depends_on: ["Gateway"]
+    endpoints:
+      - url: "https://example.com/auth"
+`,
+			message: "dependency cycle Gateway -> Auth -> Gateway",
+		},
+		{
+			name: "Maintenance without schedule",
+			config: `
+maintenance:
+  - name: "Empty"
+services:
+  - name: "Maintenance"
+    endpoints:
+      - url: "https://example.com"
+`,
+			message: "either start and end or cron and duration must be set",
+		},
+		{
+			name: "Maintenance ending before it starts",
+			config: `
+services:
+  - name: "Maintenance"
+    maintenance:
+      - start: "2025-01-10T23:00:00Z"
+        end: "2025-01-10T22:00:00Z"
+    endpoints:
+      - url: "https://example.com"
+`,
+			message: "service Maintenance, maintenance window 1: end",
+		},
+		{
+			name: "Maintenance with invalid cron",
+			config: `
+services:
+  - name: "Maintenance"
+    maintenance:
+      - cron: "0 25 * * *"
+        duration: "1h"
+    endpoints:
+      - url: "https://example.com"
+`,
+			message: `invalid hour "25"`,
+		},
+		{
+			name: "Unknown SMTP auth",
+			config: `
+notifications:
+  enabled: true
+  methods: ["email"]
+  email:
+    smtp_host: "smtp.example.com"
+    auth: "digest-md5"
+services:
+  - name: "Email"
+    endpoints:
+      - url: "https://example.com"
+`,
+			message: `email notification: unsupported SMTP auth "digest-md5"`,
+		},
+		{
+			name: "Unknown webhook signing algorithm",
+			config: `
+notifications:
+  enabled: true
+  methods: ["webhook"]
+  webhook:
+    url: "https://example.com/hook"
+    signing:
+      algorithm: "md5"
+services:
+  - name: "Webhook"
+    endpoints:
+      - url: "https://example.com"
+`,
+			message: `webhook notification: unsupported signing algorithm "md5"`,
+		},
+		{
+			name: "Unknown service severity",
+			config: `
+services:
+  - name: "Payments"
+    severity: "urgent"
+    endpoints:
+      - url: "https://example.com"
+`,
+			message: `service Payments: unsupported severity "urgent"`,
+		},
+		{
+			name: "Route to a method that is not configured",
+			config: `
+notifications:
+  enabled: true
+  methods: ["email"]
+  routes:
+    - services: ["Payments"]
+      methods: ["webhook"]
+services:
+  - name: "Payments"
+    endpoints:
+      - url: "https://example.com"
+`,
+			message: `notification route 1: method "webhook" is not in the notification methods`,
+		},
+		{
+			name: "Route to an unknown service",
+			config: `
+notifications:
+  enabled: true
+  methods: ["email"]
+  routes:
+    - services: ["Payment"]
+      methods: ["email"]
+services:
+  - name: "Payments"
+    endpoints:
+      - url: "https://example.com"
+`,
+			message: `notification route 1: unknown service "Payment"`,
+		},
+		{
+			name: "Digest of a method that is not configured",
+			config: `
+notifications:
+  enabled: true
+  methods: ["email"]
+  digests:
+    webhook:
+      interval: "daily"
+services:
+  - name: "Digest"
+    endpoints:
+      - url: "https://example.com"
+`,
+			message: `digest of "webhook": method is not in the notification methods`,
+		},
+		{
+			name: "Message template of a method formatting its own message",
+			config: `
+notifications:
+  enabled: true
+  methods: ["slack"]
+  templates:
+    channels:
+      slack:
+        message: "templates/slack.tmpl"
+services:
+  - name: "Templates"
+    endpoints:
+      - url: "https://example.com"
+`,
+			message: `templates of "slack": method formats its own message, only the title template is used`,
+		},
+		{
+			name: "Template of an event only method",
+			config: `
+notifications:
+  enabled: true
+  methods: ["pagerduty"]
+  templates:
+    channels:
+      pagerduty:
+        title: "templates/pagerduty.tmpl"
+services:
+  - name: "Templates"
+    endpoints:
+      - url: "https://example.com"
+`,
+			message: `templates of "pagerduty": method sends events and does not use templates`,
+		},
+		{
+			name: "Digest of an event only method",
+			config: `
+notifications:
+  enabled: true
+  methods: ["pagerduty"]
+  digests:
+    pagerduty:
+      interval: "daily"
+  pagerduty:
+    routing_key: "key"
+services:
+  - name: "Digest"
+    endpoints:
+      - url: "https://example.com"
+`,
+			message: `digest of "pagerduty": method only supports event notifications`,
+		},
+		{
+			name: "Invalid digest interval",
+			config: `
+notifications:
+  enabled: true
+  methods: ["email"]
+  digests:
+    email:
+      interval: "weekly"
+services:
+  - name: "Digest"
+    endpoints:
+      - url: "https://example.com"
+`,
+			message: `digest of "email": invalid interval "weekly"`,
+		},
+		{
+			name: "Invalid quiet hours",
+			config: `
+notifications:
+  enabled: true
+  methods: ["email"]
+  limits:
+    email:
+      quiet_hours:
+        start: "10pm"
+        end: "07:00"
+services:
+  - name: "Limits"
+    endpoints:
+      - url: "https://example.com"
+`,
+			message: `limits of "email": quiet_hours: invalid start "10pm"`,
+		},
+		{
+			name: "Unknown bypass severity",
+			config: `
+notifications:
+  enabled: true
+  methods: ["email"]
+  limits:
+    email:
+      max_per_hour: 10
+      bypass_severity: "urgent"
+services:
+  - name: "Limits"
+    endpoints:
+      - url: "https://example.com"
+`,
+			message: `limits of "email": bypass_severity: unsupported severity "urgent"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadConfigs(writeConfig(t, tt.config))
+			if err == nil {
+				t.Fatal("Expected an error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("Expected error to contain %q, got %v", tt.message, err)
+			}
+		})
+	}
+}
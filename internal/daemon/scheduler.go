@@ -0,0 +1,112 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/checker"
+	checkerTypes "github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+)
+
+// RoundFunc is called after every check round.
+// checkedResult holds the services checked in this round, latestResult the latest result of every service checked so far, both in config order.
+type RoundFunc func(checkedResult, latestResult []checkerTypes.Service)
+
+// Scheduler checks every service on its own interval until it is stopped
+type Scheduler struct {
+	cfg     *configure.Configure
+	onRound RoundFunc
+	nextRun []time.Time
+	latest  []*checkerTypes.Service
+}
+
+// NewScheduler creates a new scheduler, every service is due immediately
+func NewScheduler(cfg *configure.Configure, onRound RoundFunc) *Scheduler {
+	now := time.Now()
+	nextRun := make([]time.Time, len(cfg.Services))
+	for i := range nextRun {
+		nextRun[i] = now
+	}
+
+	return &Scheduler{
+		cfg:     cfg,
+		onRound: onRound,
+		nextRun: nextRun,
+		latest:  make([]*checkerTypes.Service, len(cfg.Services)),
+	}
+}
+
+// Run runs check rounds until the context is cancelled.
+// A round that is in progress is always completed before Run returns.
+func (s *Scheduler) Run(ctx context.Context) error {
+	if len(s.nextRun) == 0 {
+		return errors.New("no services to schedule")
+	}
+
+	for {
+		if due := s.dueServices(time.Now()); len(due) > 0 {
+			s.runRound(due)
+		}
+
+		timer := time.NewTimer(time.Until(s.earliestRun()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			log.Println("Scheduler stopped")
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// dueServices returns the indexes of the services whose next run is not after now
+func (s *Scheduler) dueServices(now time.Time) []int {
+	var due []int
+	for i, next := range s.nextRun {
+		if !next.After(now) {
+			due = append(due, i)
+		}
+	}
+	return due
+}
+
+// earliestRun returns the time at which the next service becomes due
+func (s *Scheduler) earliestRun() time.Time {
+	earliest := s.nextRun[0]
+	for _, next := range s.nextRun[1:] {
+		if next.Before(earliest) {
+			earliest = next
+		}
+	}
+	return earliest
+}
+
+// runRound checks the due services and reports the results
+func (s *Scheduler) runRound(due []int) {
+	startTime := time.Now()
+
+	roundCfg := *s.cfg
+	roundCfg.Services = make([]configure.Service, 0, len(due))
+	for _, i := range due {
+		roundCfg.Services = append(roundCfg.Services, s.cfg.Services[i])
+	}
+	log.Printf("Checking %d service(s)", len(due))
+	checkedResult := checker.CheckServices(&roundCfg)
+
+	for k, i := range due {
+		s.latest[i] = &checkedResult[k]
+		s.nextRun[i] = startTime.Add(s.cfg.Services[i].ParsedInterval)
+	}
+
+	var latestResult []checkerTypes.Service
+	for _, result := range s.latest {
+		if result != nil {
+			latestResult = append(latestResult, *result)
+		}
+	}
+
+	s.onRound(checkedResult, latestResult)
+}
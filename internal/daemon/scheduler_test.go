@@ -0,0 +1,79 @@
+package daemon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	checkerTypes "github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+)
+
+func TestScheduler_RunsServicesOnTheirOwnInterval(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := &configure.Configure{
+		Concurrency: 4,
+		Services: []configure.Service{
+			{
+				Name:           "fast",
+				Endpoints:      []configure.Endpoint{{URL: server.URL + "/fast", ParsedURL: server.URL + "/fast"}},
+				Timeout:        1,
+				MaxRetryTimes:  1,
+				Concurrency:    4,
+				ParsedInterval: 50 * time.Millisecond,
+			},
+			{
+				Name:           "slow",
+				Endpoints:      []configure.Endpoint{{URL: server.URL + "/slow", ParsedURL: server.URL + "/slow"}},
+				Timeout:        1,
+				MaxRetryTimes:  1,
+				Concurrency:    4,
+				ParsedInterval: time.Hour,
+			},
+		},
+	}
+
+	var mu sync.Mutex
+	checkedNum := make(map[string]int)
+	var lastLatest []checkerTypes.Service
+	scheduler := NewScheduler(cfg, func(checkedResult, latestResult []checkerTypes.Service) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, service := range checkedResult {
+			checkedNum[service.Name]++
+		}
+		lastLatest = latestResult
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	if err := scheduler.Run(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("Expected Run to stop with the context error, got %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if checkedNum["slow"] != 1 {
+		t.Errorf("Expected slow service to be checked once, got %d", checkedNum["slow"])
+	}
+	if checkedNum["fast"] < 3 {
+		t.Errorf("Expected fast service to be checked at least 3 times, got %d", checkedNum["fast"])
+	}
+	if len(lastLatest) != 2 || lastLatest[0].Name != "fast" || lastLatest[1].Name != "slow" {
+		t.Errorf("Expected latest results of both services in config order, got %v", lastLatest)
+	}
+}
+
+func TestScheduler_NoServices(t *testing.T) {
+	scheduler := NewScheduler(&configure.Configure{}, func(_, _ []checkerTypes.Service) {})
+	if err := scheduler.Run(context.Background()); err == nil {
+		t.Error("Expected an error when no services are configured")
+	}
+}
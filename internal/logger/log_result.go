@@ -10,6 +10,13 @@ import (
 
 // GetLog writes check results to JSON file
 func GetLog(currentCheckResult []checker.Service, maxLogDays int, logPath string) (logger.Logger, error) {
+	return UpdateLog(currentCheckResult, currentCheckResult, maxLogDays, logPath)
+}
+
+// UpdateLog merges the results of the services checked in this round into the log.
+// knownCheckResult holds the latest result of every monitored service and decides which log entries are kept,
+// so services that were not checked in this round keep their history.
+func UpdateLog(checkedResult, knownCheckResult []checker.Service, maxLogDays int, logPath string) (logger.Logger, error) {
 	// Load existing log data
 	previousLog, err := common.ReadLogs(logPath)
 	if err != nil {
@@ -18,10 +25,10 @@ func GetLog(currentCheckResult []checker.Service, maxLogDays int, logPath string
 	}
 
 	// Use filtered data for further processing
-	previousLog = common.FilterLogs(previousLog, currentCheckResult)
+	previousLog = common.FilterLogs(previousLog, knownCheckResult)
 
 	// Merge new check results with existing log data
-	currentLog := common.MergeLogs(previousLog, currentCheckResult, maxLogDays)
+	currentLog := common.MergeLogs(previousLog, checkedResult, maxLogDays)
 
 	return currentLog, nil
 }
@@ -0,0 +1,112 @@
+package channels
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/wcy-dt/ponghub/internal/common/params"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+// dingTalkMaxTextBytes keeps messages below the 20000 byte limit of DingTalk robots, leaving room for the title
+const dingTalkMaxTextBytes = 18000
+
+type (
+	// dingTalkText is the content of a text message
+	dingTalkText struct {
+		Content string `json:"content"`
+	}
+
+	// dingTalkMarkdown is the content of a markdown message
+	dingTalkMarkdown struct {
+		Title string `json:"title"`
+		Text  string `json:"text"`
+	}
+
+	// dingTalkMessage is the payload posted to a DingTalk robot
+	dingTalkMessage struct {
+		MsgType  string            `json:"msgtype"`
+		Text     *dingTalkText     `json:"text,omitempty"`
+		Markdown *dingTalkMarkdown `json:"markdown,omitempty"`
+	}
+)
+
+// DingTalkNotifier implements DingTalk group robot notifications
+type DingTalkNotifier struct {
+	config *configure.DingTalkConfig
+}
+
+// NewDingTalkNotifier creates a new DingTalk notifier
+func NewDingTalkNotifier(config *configure.DingTalkConfig) *DingTalkNotifier {
+	return &DingTalkNotifier{config: config}
+}
+
+// Send sends a plain text message, split into several messages if it is too long
+func (d *DingTalkNotifier) Send(title, message string) error {
+	var messages []dingTalkMessage
+	for _, chunk := range splitTextBy(title+"\n\n"+message, dingTalkMaxTextBytes, utf8.RuneLen) {
+		messages = append(messages, dingTalkMessage{MsgType: "text", Text: &dingTalkText{Content: chunk}})
+	}
+	return d.postAll(messages)
+}
+
+// SendNotification sends a markdown message with one block per service and incident type
+func (d *DingTalkNotifier) SendNotification(notification notifierTypes.Notification) error {
+	var messages []dingTalkMessage
+	// DingTalk only breaks lines at blank lines
+	text := formatMarkdownSections(notification, "\n\n")
+	for _, chunk := range splitTextBy(text, dingTalkMaxTextBytes, utf8.RuneLen) {
+		messages = append(messages, dingTalkMessage{
+			MsgType: "markdown",
+			Markdown: &dingTalkMarkdown{
+				Title: notification.Title,
+				Text:  "### " + escapeMarkdown(notification.Title) + "\n\n" + chunk,
+			},
+		})
+	}
+	return d.postAll(messages)
+}
+
+// postAll posts the messages in order to the configured robot, signing every request if a secret is configured
+func (d *DingTalkNotifier) postAll(messages []dingTalkMessage) error {
+	resolver := params.NewParameterResolver()
+	webhookURL := d.config.WebhookURL
+	if webhookURL == "" {
+		webhookURL = os.Getenv("DINGTALK_WEBHOOK_URL")
+	}
+	if webhookURL == "" {
+		return fmt.Errorf("dingtalk webhook URL not configured")
+	}
+	webhookURL = resolver.ResolveParameters(webhookURL)
+
+	secret := resolver.ResolveParameters(d.config.Secret)
+	if secret == "" {
+		secret = os.Getenv("DINGTALK_SECRET")
+	}
+
+	for i, message := range messages {
+		requestURL := webhookURL
+		if secret != "" {
+			requestURL = signDingTalkURL(webhookURL, secret, time.Now())
+		}
+		if err := sendRobotMessage(requestURL, message, d.config.Retries, d.config.Timeout); err != nil {
+			return fmt.Errorf("failed to send message %d of %d: %w", i+1, len(messages), err)
+		}
+	}
+	return nil
+}
+
+// signDingTalkURL adds the timestamp in milliseconds and its signature to the webhook URL
+func signDingTalkURL(webhookURL, secret string, now time.Time) string {
+	timestamp := now.UnixMilli()
+	separator := "?"
+	if strings.Contains(webhookURL, "?") {
+		separator = "&"
+	}
+	return fmt.Sprintf("%s%stimestamp=%d&sign=%s", webhookURL, separator, timestamp, url.QueryEscape(signRobotRequest(timestamp, secret, false)))
+}
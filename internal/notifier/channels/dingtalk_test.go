@@ -0,0 +1,95 @@
+package channels
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+func TestSignDingTalkURL(t *testing.T) {
+	signed := signDingTalkURL("https://oapi.dingtalk.com/robot/send?access_token=XXX", "SEC000", time.UnixMilli(1700000000000))
+
+	expected := "https://oapi.dingtalk.com/robot/send?access_token=XXX&timestamp=1700000000000&sign=ltBBey5eZrWKh1cPzFIdz3v3xpkc4Tjx4lLsPSHqdtA%3D"
+	if signed != expected {
+		t.Errorf("Expected %s, got %s", expected, signed)
+	}
+}
+
+func TestDingTalkNotifier_SendNotification(t *testing.T) {
+	var messages []dingTalkMessage
+	var query []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message dingTalkMessage
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Errorf("Failed to decode DingTalk message: %v", err)
+		}
+		messages = append(messages, message)
+		query = append(query, r.URL.RawQuery)
+		_, _ = w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer server.Close()
+
+	notification := notifierTypes.Notification{
+		Title: "🚨 PongHub Service Status Alert",
+		Events: []notifierTypes.Event{{
+			ServiceName: "Gateway",
+			Endpoint:    checker.Endpoint{URL: "https://gateway.example.com/health", StatusCode: 502, AttemptNum: 3},
+			Status:      "down", PreviousStatus: "up",
+		}},
+		StatusPageURL: "https://status.example.com",
+	}
+	notifier := NewDingTalkNotifier(&configure.DingTalkConfig{WebhookURL: server.URL + "?access_token=XXX", Secret: "SEC000"})
+	if err := notifier.SendNotification(notification); err != nil {
+		t.Fatalf("SendNotification failed: %v", err)
+	}
+
+	if len(messages) != 1 || messages[0].MsgType != "markdown" || messages[0].Markdown == nil {
+		t.Fatalf("Expected a markdown message, got %+v", messages)
+	}
+	if messages[0].Markdown.Title != notification.Title {
+		t.Errorf("Expected the title %q, got %q", notification.Title, messages[0].Markdown.Title)
+	}
+	for _, expected := range []string{
+		"### 🚨 PongHub Service Status Alert\n\n",
+		"**🔴 Gateway is down**\n\n",
+		"- [https://gateway.example.com/health](https://gateway.example.com/health)\n\n",
+		"Status code 502 · 0/3 attempts successful",
+		"[View status page](https://status.example.com)",
+	} {
+		if !strings.Contains(messages[0].Markdown.Text, expected) {
+			t.Errorf("Expected the text to contain %q, got:\n%s", expected, messages[0].Markdown.Text)
+		}
+	}
+	if !strings.HasPrefix(query[0], "access_token=XXX&timestamp=") || !strings.Contains(query[0], "&sign=") {
+		t.Errorf("Expected a signed request, got %s", query[0])
+	}
+}
+
+func TestDingTalkNotifier_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"errcode":310000,"errmsg":"sign not match"}`))
+	}))
+	defer server.Close()
+
+	notifier := NewDingTalkNotifier(&configure.DingTalkConfig{WebhookURL: server.URL, Secret: "wrong"})
+	if err := notifier.Send("Title", "Message"); err == nil || !strings.Contains(err.Error(), "sign not match") {
+		t.Errorf("Expected the error of the robot, got %v", err)
+	}
+}
+
+func TestSignRobotRequest_Base64(t *testing.T) {
+	for _, stringAsKey := range []bool{false, true} {
+		sign, err := base64.StdEncoding.DecodeString(signRobotRequest(1700000000, "SEC000", stringAsKey))
+		if err != nil || len(sign) != 32 {
+			t.Errorf("Expected a base64 encoded HMAC-SHA256, got %d bytes and %v", len(sign), err)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package channels
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/common/params"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+// Limits of Discord messages
+const (
+	discordMaxContentLength     = 2000
+	discordMaxTitleLength       = 256
+	discordMaxDescriptionLength = 4096
+	discordMaxEmbeds            = 10
+	discordMaxEmbedsLength      = 6000
+)
+
+type (
+	// discordFooter is the footer of a Discord embed
+	discordFooter struct {
+		Text string `json:"text"`
+	}
+
+	// discordEmbed is a colour-coded Discord embed
+	discordEmbed struct {
+		Title       string         `json:"title,omitempty"`
+		Description string         `json:"description,omitempty"`
+		URL         string         `json:"url,omitempty"`
+		Color       int            `json:"color,omitempty"`
+		Timestamp   string         `json:"timestamp,omitempty"`
+		Footer      *discordFooter `json:"footer,omitempty"`
+	}
+
+	// discordAllowedMentions controls which mentions in a message notify users
+	discordAllowedMentions struct {
+		Parse []string `json:"parse"`
+	}
+
+	// discordMessage is the payload posted to a Discord webhook
+	discordMessage struct {
+		Content         string                 `json:"content,omitempty"`
+		Username        string                 `json:"username,omitempty"`
+		AvatarURL       string                 `json:"avatar_url,omitempty"`
+		Embeds          []discordEmbed         `json:"embeds,omitempty"`
+		AllowedMentions discordAllowedMentions `json:"allowed_mentions"`
+	}
+)
+
+// DiscordNotifier implements Discord notifications through webhooks with embeds
+type DiscordNotifier struct {
+	config *configure.DiscordConfig
+}
+
+// NewDiscordNotifier creates a new Discord notifier
+func NewDiscordNotifier(config *configure.DiscordConfig) *DiscordNotifier {
+	return &DiscordNotifier{config: config}
+}
+
+// Send sends a plain notification with the message as preformatted text, split into several messages if it is too long
+func (d *DiscordNotifier) Send(title, message string) error {
+	// code blocks cannot contain their own fence, so it is broken up with a zero-width space
+	message = strings.ReplaceAll(message, "```", "`\u200b``")
+
+	messages := []discordMessage{d.newMessage("**" + escapeMarkdown(truncateText(title, discordMaxContentLength-4)) + "**")}
+	for _, chunk := range splitText(message, discordMaxContentLength-8) {
+		messages = append(messages, d.newMessage("```\n"+chunk+"\n```"))
+	}
+	return d.postAll(messages)
+}
+
+// SendNotification sends one embed per service and incident type, split into several messages if there are too many
+func (d *DiscordNotifier) SendNotification(notification notifierTypes.Notification) error {
+	return d.postAll(d.buildMessages(notification))
+}
+
+// buildMessages builds the Discord messages of a notification, the first one holds the title
+func (d *DiscordNotifier) buildMessages(notification notifierTypes.Notification) []discordMessage {
+	timestamp := notification.GeneratedAt.Format(time.RFC3339)
+
+	var embeds []discordEmbed
+	for _, section := range buildSections(notification) {
+		embeds = append(embeds, newDiscordEmbed(section, notification.StatusPageURL, timestamp))
+	}
+	if len(embeds) > 0 {
+		embeds[len(embeds)-1].Footer = &discordFooter{Text: summarizeNotification(notification)}
+	}
+
+	// every message holds at most 10 embeds and 6000 characters of embed text
+	messages := []discordMessage{d.newMessage("**" + escapeMarkdown(truncateText(notification.Title, discordMaxContentLength-4)) + "**")}
+	current := &messages[0]
+	currentLength := 0
+	for _, embed := range embeds {
+		length := embedLength(embed)
+		if len(current.Embeds) == discordMaxEmbeds || (len(current.Embeds) > 0 && currentLength+length > discordMaxEmbedsLength) {
+			messages = append(messages, d.newMessage(""))
+			current = &messages[len(messages)-1]
+			currentLength = 0
+		}
+		current.Embeds = append(current.Embeds, embed)
+		currentLength += length
+	}
+	return messages
+}
+
+// newMessage creates a message with the configured username and avatar, mentions in the text never notify anyone
+func (d *DiscordNotifier) newMessage(content string) discordMessage {
+	return discordMessage{
+		Content:         content,
+		Username:        d.config.Username,
+		AvatarURL:       d.config.AvatarURL,
+		AllowedMentions: discordAllowedMentions{Parse: []string{}},
+	}
+}
+
+// postAll posts the messages in order to the configured webhook, falling back to the DISCORD_WEBHOOK_URL environment variable
+func (d *DiscordNotifier) postAll(messages []discordMessage) error {
+	url := d.config.WebhookURL
+	if url == "" {
+		url = os.Getenv("DISCORD_WEBHOOK_URL")
+	}
+	if url == "" {
+		return fmt.Errorf("discord webhook URL not configured")
+	}
+	url = params.NewParameterResolver().ResolveParameters(url)
+
+	for i, message := range messages {
+		if err := sendHTTPRequest(url, "POST", message, nil, d.config.Retries, d.config.Timeout, false); err != nil {
+			return fmt.Errorf("failed to send message %d of %d: %w", i+1, len(messages), err)
+		}
+	}
+	return nil
+}
+
+// newDiscordEmbed creates the colour-coded embed of a section
+func newDiscordEmbed(section notificationSection, statusPageURL, timestamp string) discordEmbed {
+	var description strings.Builder
+	for _, item := range section.Items {
+		description.WriteString(formatDiscordURL(item.URL) + "\n")
+		for _, detail := range item.Details {
+			description.WriteString(escapeMarkdown(detail) + "\n")
+		}
+	}
+	if len(section.ImpactedServices) > 0 {
+		description.WriteString(fmt.Sprintf("⛓️ Impacted services: %s\n", escapeMarkdown(strings.Join(section.ImpactedServices, ", "))))
+	}
+
+	return discordEmbed{
+		Title:       truncateText(fmt.Sprintf("%s %s %s", section.Emoji(), section.ServiceName, section.Summary), discordMaxTitleLength),
+		Description: truncateText(description.String(), discordMaxDescriptionLength),
+		URL:         statusPageURL,
+		Color:       parseHexColor(section.Color()),
+		Timestamp:   timestamp,
+	}
+}
+
+// formatDiscordURL shows HTTP endpoints as links without preview, other endpoints such as host:port as code
+func formatDiscordURL(url string) string {
+	if isHTTPURL(url) {
+		return "<" + url + ">"
+	}
+	return "`" + strings.ReplaceAll(url, "`", "'") + "`"
+}
+
+// embedLength returns the number of characters of an embed counted towards the message limit
+func embedLength(embed discordEmbed) int {
+	length := len([]rune(embed.Title)) + len([]rune(embed.Description))
+	if embed.Footer != nil {
+		length += len([]rune(embed.Footer.Text))
+	}
+	return length
+}
+
+// parseHexColor converts a colour such as #ff4136 to its integer value
+func parseHexColor(color string) int {
+	value, err := strconv.ParseInt(strings.TrimPrefix(color, "#"), 16, 32)
+	if err != nil {
+		return 0
+	}
+	return int(value)
+}
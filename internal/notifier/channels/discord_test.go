@@ -0,0 +1,169 @@
+package channels
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+// newDiscordServer starts a test server that records the Discord messages posted to it
+func newDiscordServer(t *testing.T, messages *[]discordMessage) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message discordMessage
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Errorf("Failed to decode Discord message: %v", err)
+		}
+		*messages = append(*messages, message)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDiscordNotifier_SendNotification(t *testing.T) {
+	var messages []discordMessage
+	server := newDiscordServer(t, &messages)
+
+	notification := notifierTypes.Notification{
+		Title: "🚨 PongHub Service Status Alert",
+		Events: []notifierTypes.Event{
+			{
+				ServiceName: "Gateway",
+				Endpoint: checker.Endpoint{
+					URL:            "https://gateway.example.com/health",
+					StatusCode:     502,
+					AttemptNum:     3,
+					FailureDetails: []string{"body does not match *ok*"},
+				},
+				Status: "down", PreviousStatus: "up",
+			},
+			{ServiceName: "Billing", Endpoint: checker.Endpoint{URL: "https://billing.example.com"}, Status: "down", PreviousStatus: "up", ImpactedBy: "Gateway"},
+			{ServiceName: "Search", Endpoint: checker.Endpoint{URL: "https://search.example.com"}, Status: "up", PreviousStatus: "down", Duration: 90 * time.Second},
+		},
+		CertProblems: map[string][]checker.Endpoint{
+			"Website": {{URL: "https://example.com", IsHTTPS: true, IsCertExpired: true}},
+		},
+		GeneratedAt:   time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		StatusPageURL: "https://status.example.com",
+	}
+
+	notifier := NewDiscordNotifier(&configure.DiscordConfig{WebhookURL: server.URL, Username: "PongHub"})
+	if err := notifier.SendNotification(notification); err != nil {
+		t.Fatalf("SendNotification failed: %v", err)
+	}
+
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	message := messages[0]
+	if message.Content != "**"+notification.Title+"**" || message.Username != "PongHub" {
+		t.Errorf("Expected the title as content and the configured username, got %q by %q", message.Content, message.Username)
+	}
+	if message.AllowedMentions.Parse == nil || len(message.AllowedMentions.Parse) != 0 {
+		t.Errorf("Expected mentions to be disabled, got %+v", message.AllowedMentions)
+	}
+
+	expectedColors := []int{0xff4136, 0x2ecc40, 0xff4136}
+	if len(message.Embeds) != len(expectedColors) {
+		t.Fatalf("Expected %d embeds, got %+v", len(expectedColors), message.Embeds)
+	}
+	for i, color := range expectedColors {
+		embed := message.Embeds[i]
+		if embed.Color != color || embed.URL != notification.StatusPageURL || embed.Timestamp != "2025-01-01T10:00:00Z" {
+			t.Errorf("Expected embed %d to be %06x and link to the status page, got %+v", i, color, embed)
+		}
+	}
+
+	down := message.Embeds[0]
+	if down.Title != "🔴 Gateway is down" {
+		t.Errorf("Expected the service outage as title, got %q", down.Title)
+	}
+	for _, expected := range []string{
+		"<https://gateway.example.com/health>",
+		"Status code 502 · 0/3 attempts successful",
+		`Last error: body does not match \*ok\*`,
+		"Impacted services: Billing",
+	} {
+		if !strings.Contains(down.Description, expected) {
+			t.Errorf("Expected the down embed to contain %q, got:\n%s", expected, down.Description)
+		}
+	}
+	if !strings.Contains(message.Embeds[2].Description, "Certificate expired") {
+		t.Errorf("Expected the certificate embed to report the expiry, got:\n%s", message.Embeds[2].Description)
+	}
+	if footer := message.Embeds[2].Footer; footer == nil || !strings.Contains(footer.Text, "Unavailable: 1") {
+		t.Errorf("Expected the last embed to hold the summary, got %+v", footer)
+	}
+}
+
+func TestDiscordNotifier_BuildMessages_Batches(t *testing.T) {
+	notification := notifierTypes.Notification{Title: "Alert"}
+	for i := range 25 {
+		notification.Events = append(notification.Events, notifierTypes.Event{
+			ServiceName: fmt.Sprintf("Service %d", i),
+			Endpoint:    checker.Endpoint{URL: "https://example.com", FailureDetails: []string{strings.Repeat("x", 500)}},
+			Status:      "down", PreviousStatus: "up",
+		})
+	}
+
+	messages := NewDiscordNotifier(&configure.DiscordConfig{}).buildMessages(notification)
+
+	embedNum := 0
+	for i, message := range messages {
+		length := 0
+		for _, embed := range message.Embeds {
+			length += embedLength(embed)
+		}
+		if len(message.Embeds) > discordMaxEmbeds || length > discordMaxEmbedsLength {
+			t.Errorf("Expected message %d within the limits, got %d embeds with %d characters", i, len(message.Embeds), length)
+		}
+		if (i == 0) != (message.Content != "") {
+			t.Errorf("Expected only the first message to hold the title, got %q in message %d", message.Content, i)
+		}
+		embedNum += len(message.Embeds)
+	}
+	if embedNum != 25 || len(messages) < 3 {
+		t.Errorf("Expected 25 embeds over at least 3 messages, got %d over %d", embedNum, len(messages))
+	}
+}
+
+func TestDiscordNotifier_Send(t *testing.T) {
+	var messages []discordMessage
+	server := newDiscordServer(t, &messages)
+
+	message := strings.Repeat("endpoint https://example.com is down\n", 100)
+	notifier := NewDiscordNotifier(&configure.DiscordConfig{WebhookURL: server.URL})
+	if err := notifier.Send("Title", message); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	if len(messages) < 3 || messages[0].Content != "**Title**" {
+		t.Fatalf("Expected the title followed by several chunks, got %d messages", len(messages))
+	}
+	var text strings.Builder
+	for _, m := range messages[1:] {
+		if len([]rune(m.Content)) > discordMaxContentLength {
+			t.Errorf("Expected messages of at most %d characters, got %d", discordMaxContentLength, len([]rune(m.Content)))
+		}
+		text.WriteString(strings.TrimSuffix(strings.TrimPrefix(m.Content, "```\n"), "\n```"))
+	}
+	if text.String() != message {
+		t.Errorf("Expected the message to be sent unchanged, got:\n%s", text.String())
+	}
+}
+
+func TestDiscordNotifier_MissingWebhookURL(t *testing.T) {
+	t.Setenv("DISCORD_WEBHOOK_URL", "")
+	notifier := NewDiscordNotifier(&configure.DiscordConfig{})
+	if err := notifier.Send("Title", "Message"); err == nil {
+		t.Error("Expected an error without a webhook URL")
+	}
+}
@@ -1,14 +1,19 @@
 package channels
 
 import (
+	"bytes"
 	"crypto/tls"
 	"fmt"
 	"log"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
 	"net/smtp"
-	"os"
+	"net/textproto"
 	"time"
 
 	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
 )
 
 // EmailNotifier implements email notifications
@@ -21,14 +26,29 @@ func NewEmailNotifier(config *configure.EmailConfig) *EmailNotifier {
 	return &EmailNotifier{config: config}
 }
 
-// Send sends an email notification with secure SMTP connection
+// Send sends a plain text email notification with secure SMTP connection
 func (e *EmailNotifier) Send(title, message string) error {
-	// Get SMTP credentials from environment variables
-	username := os.Getenv("SMTP_USERNAME")
-	password := os.Getenv("SMTP_PASSWORD")
+	return e.send(e.buildEmailBody(title, message))
+}
+
+// SendNotification sends a multipart email with the plain text message and an HTML part with a table per service
+func (e *EmailNotifier) SendNotification(notification notifierTypes.Notification) error {
+	html, err := buildEmailHTML(notification, e.config.Sparkline)
+	if err != nil {
+		return err
+	}
+	emailBody, err := e.buildMultipartEmailBody(notification.Title, notification.Message, html)
+	if err != nil {
+		return err
+	}
+	return e.send(emailBody)
+}
 
-	if username == "" || password == "" {
-		return fmt.Errorf("SMTP credentials not found in environment variables")
+// send sends the email with secure SMTP connection
+func (e *EmailNotifier) send(emailBody string) error {
+	auth, err := e.newSMTPAuth()
+	if err != nil {
+		return err
 	}
 
 	addr := fmt.Sprintf("%s:%d", e.config.SMTPHost, e.config.SMTPPort)
@@ -36,19 +56,21 @@ func (e *EmailNotifier) Send(title, message string) error {
 	// Use secure connection based on configuration
 	if e.config.UseTLS {
 		// Direct TLS connection (typically port 465)
-		return e.sendWithTLS(addr, username, password, title, message)
+		return e.sendWithTLS(addr, auth, emailBody)
 	} else if e.config.UseStartTLS {
 		// STARTTLS connection (typically port 587)
-		return e.sendWithStartTLS(addr, username, password, title, message)
+		return e.sendWithStartTLS(addr, auth, emailBody)
 	} else {
 		// Plain connection - warn about security risk
-		fmt.Printf("WARNING: Using plain SMTP connection without TLS. This is insecure and credentials will be sent in plain text. Consider enabling use_tls or use_starttls in your configuration.\n")
-		return e.sendPlain(addr, username, password, title, message)
+		if auth != nil {
+			fmt.Printf("WARNING: Using plain SMTP connection without TLS. This is insecure and credentials will be sent in plain text. Consider enabling use_tls or use_starttls in your configuration.\n")
+		}
+		return e.sendPlain(addr, auth, emailBody)
 	}
 }
 
 // sendWithTLS sends email using direct TLS connection
-func (e *EmailNotifier) sendWithTLS(addr, username, password, title, message string) error {
+func (e *EmailNotifier) sendWithTLS(addr string, auth smtp.Auth, emailBody string) error {
 	tlsConfig := &tls.Config{
 		ServerName:         e.config.SMTPHost,
 		InsecureSkipVerify: e.config.SkipVerify,
@@ -74,16 +96,15 @@ func (e *EmailNotifier) sendWithTLS(addr, username, password, title, message str
 		}
 	}(client)
 
-	auth := smtp.PlainAuth("", username, password, e.config.SMTPHost)
-	if err := client.Auth(auth); err != nil {
-		return fmt.Errorf("SMTP authentication failed: %w", err)
+	if err := authenticate(client, auth); err != nil {
+		return err
 	}
 
-	return e.sendMessage(client, title, message)
+	return e.sendMessage(client, emailBody)
 }
 
 // sendWithStartTLS sends email using STARTTLS
-func (e *EmailNotifier) sendWithStartTLS(addr, username, password, title, message string) error {
+func (e *EmailNotifier) sendWithStartTLS(addr string, auth smtp.Auth, emailBody string) error {
 	client, err := smtp.Dial(addr)
 	if err != nil {
 		return fmt.Errorf("failed to connect to SMTP server: %w", err)
@@ -103,16 +124,15 @@ func (e *EmailNotifier) sendWithStartTLS(addr, username, password, title, messag
 		return fmt.Errorf("failed to start TLS: %w", err)
 	}
 
-	auth := smtp.PlainAuth("", username, password, e.config.SMTPHost)
-	if err := client.Auth(auth); err != nil {
-		return fmt.Errorf("SMTP authentication failed: %w", err)
+	if err := authenticate(client, auth); err != nil {
+		return err
 	}
 
-	return e.sendMessage(client, title, message)
+	return e.sendMessage(client, emailBody)
 }
 
 // sendPlain sends email using plain connection (not recommended)
-func (e *EmailNotifier) sendPlain(addr, username, password, title, message string) error {
+func (e *EmailNotifier) sendPlain(addr string, auth smtp.Auth, emailBody string) error {
 	client, err := smtp.Dial(addr)
 	if err != nil {
 		return fmt.Errorf("failed to connect to SMTP server: %w", err)
@@ -123,16 +143,26 @@ func (e *EmailNotifier) sendPlain(addr, username, password, title, message strin
 		}
 	}(client)
 
-	auth := smtp.PlainAuth("", username, password, e.config.SMTPHost)
+	if err := authenticate(client, auth); err != nil {
+		return err
+	}
+
+	return e.sendMessage(client, emailBody)
+}
+
+// authenticate authenticates the SMTP client, a nil auth skips authentication for relays that do not need it
+func authenticate(client *smtp.Client, auth smtp.Auth) error {
+	if auth == nil {
+		return nil
+	}
 	if err := client.Auth(auth); err != nil {
 		return fmt.Errorf("SMTP authentication failed: %w", err)
 	}
-
-	return e.sendMessage(client, title, message)
+	return nil
 }
 
 // sendMessage sends the actual email message using the SMTP client
-func (e *EmailNotifier) sendMessage(client *smtp.Client, title, message string) error {
+func (e *EmailNotifier) sendMessage(client *smtp.Client, emailBody string) error {
 	// Set sender
 	if err := client.Mail(e.config.From); err != nil {
 		return fmt.Errorf("failed to set sender: %w", err)
@@ -151,7 +181,6 @@ func (e *EmailNotifier) sendMessage(client *smtp.Client, title, message string)
 		return fmt.Errorf("failed to get data writer: %w", err)
 	}
 
-	emailBody := e.buildEmailBody(title, message)
 	if _, err := writer.Write([]byte(emailBody)); err != nil {
 		return fmt.Errorf("failed to write email body: %w", err)
 	}
@@ -165,12 +194,50 @@ func (e *EmailNotifier) sendMessage(client *smtp.Client, title, message string)
 
 // buildEmailBody constructs the email body with proper headers
 func (e *EmailNotifier) buildEmailBody(title, message string) string {
+	return e.buildHeaders(title, "text/plain; charset=UTF-8") + "\r\n" + message
+}
+
+// buildMultipartEmailBody constructs a multipart/alternative email body with the plain text and HTML versions of a message,
+// mail clients show the last version they support
+func (e *EmailNotifier) buildMultipartEmailBody(title, message, html string) (string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=UTF-8", message},
+		{"text/html; charset=UTF-8", html},
+	} {
+		partWriter, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to create email part: %w", err)
+		}
+		// quoted-printable keeps the lines short and the emoji intact through every mail server
+		encoder := quotedprintable.NewWriter(partWriter)
+		if _, err := encoder.Write([]byte(part.content)); err != nil {
+			return "", fmt.Errorf("failed to write email part: %w", err)
+		}
+		if err := encoder.Close(); err != nil {
+			return "", fmt.Errorf("failed to write email part: %w", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("failed to close email body: %w", err)
+	}
+
+	contentType := fmt.Sprintf("multipart/alternative; boundary=%q", writer.Boundary())
+	return e.buildHeaders(title, contentType) + "\r\n" + body.String(), nil
+}
+
+// buildHeaders constructs the email headers, encoding the subject if it is not plain ASCII
+func (e *EmailNotifier) buildHeaders(title, contentType string) string {
 	headers := make(map[string]string)
 	headers["From"] = e.config.From
 	headers["To"] = e.formatRecipients()
-	headers["Subject"] = title
+	headers["Subject"] = mime.QEncoding.Encode("UTF-8", title)
 	headers["MIME-Version"] = "1.0"
-	headers["Content-Type"] = "text/plain; charset=UTF-8"
+	headers["Content-Type"] = contentType
 	headers["Date"] = time.Now().Format(time.RFC1123Z)
 
 	// Add custom headers if configured
@@ -183,9 +250,7 @@ func (e *EmailNotifier) buildEmailBody(title, message string) string {
 	for key, value := range headers {
 		headerStr += fmt.Sprintf("%s: %s\r\n", key, value)
 	}
-
-	// Combine headers and body
-	return headerStr + "\r\n" + message
+	return headerStr
 }
 
 // formatRecipients formats the recipient list for the To header
@@ -0,0 +1,154 @@
+package channels
+
+import (
+	"errors"
+	"fmt"
+	"net/smtp"
+	"os"
+	"strings"
+
+	"github.com/wcy-dt/ponghub/internal/common/params"
+	"github.com/wcy-dt/ponghub/internal/types/types/smtp_auth"
+)
+
+type (
+	// emailCredentials holds the resolved SMTP credentials
+	emailCredentials struct {
+		username string
+		password string
+		token    string
+	}
+
+	// loginAuth implements the LOGIN mechanism, which is not part of net/smtp
+	loginAuth struct {
+		username string
+		password string
+		step     int
+	}
+
+	// xoauth2Auth implements the XOAUTH2 mechanism with an OAuth 2.0 access token
+	xoauth2Auth struct {
+		username string
+		token    string
+	}
+)
+
+// newSMTPAuth creates the smtp.Auth of the configured mechanism, nil means that no authentication is needed
+func (e *EmailNotifier) newSMTPAuth() (smtp.Auth, error) {
+	mechanism, err := smtp_auth.ParseSMTPAuth(e.config.Auth)
+	if err != nil {
+		return nil, err
+	}
+	if mechanism == smtp_auth.NONE {
+		return nil, nil
+	}
+
+	credentials, err := e.credentials()
+	if err != nil {
+		return nil, err
+	}
+	if mechanism == smtp_auth.XOAUTH2 {
+		if credentials.username == "" || credentials.token == "" {
+			return nil, fmt.Errorf("SMTP credentials not found: xoauth2 needs a username and a token, set them in the configuration or in the SMTP_USERNAME and SMTP_OAUTH_TOKEN environment variables")
+		}
+		return &xoauth2Auth{username: credentials.username, token: credentials.token}, nil
+	}
+	if credentials.username == "" || credentials.password == "" {
+		return nil, fmt.Errorf("SMTP credentials not found in configuration or environment variables: set username and password, or SMTP_USERNAME and SMTP_PASSWORD")
+	}
+
+	switch mechanism {
+	case smtp_auth.LOGIN:
+		return &loginAuth{username: credentials.username, password: credentials.password}, nil
+	case smtp_auth.CRAMMD5:
+		return smtp.CRAMMD5Auth(credentials.username, credentials.password), nil
+	default:
+		return smtp.PlainAuth("", credentials.username, credentials.password, e.config.SMTPHost), nil
+	}
+}
+
+// credentials resolves the SMTP credentials from the configuration, the credential files or the environment, in that order
+func (e *EmailNotifier) credentials() (emailCredentials, error) {
+	var credentials emailCredentials
+	var err error
+	if credentials.username, err = resolveCredential(e.config.Username, e.config.UsernameFile, "SMTP_USERNAME"); err != nil {
+		return credentials, err
+	}
+	if credentials.password, err = resolveCredential(e.config.Password, e.config.PasswordFile, "SMTP_PASSWORD"); err != nil {
+		return credentials, err
+	}
+	if credentials.token, err = resolveCredential(e.config.Token, e.config.TokenFile, "SMTP_OAUTH_TOKEN"); err != nil {
+		return credentials, err
+	}
+	return credentials, nil
+}
+
+// resolveCredential returns the configured value with its parameters resolved, the trimmed content of the file,
+// or the environment variable
+func resolveCredential(value, file, envName string) (string, error) {
+	if value != "" {
+		return params.NewParameterResolver().ResolveParameters(value), nil
+	}
+	if file != "" {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("failed to read SMTP credential file: %w", err)
+		}
+		return strings.TrimSpace(string(content)), nil
+	}
+	return os.Getenv(envName), nil
+}
+
+// Start begins the LOGIN exchange, the username and password are sent in the following steps
+func (a *loginAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if err := checkAuthConnection(server); err != nil {
+		return "", nil, err
+	}
+	a.step = 0
+	return "LOGIN", nil, nil
+}
+
+// Next answers the username and password prompts of the server
+func (a *loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+	a.step++
+	// servers prompt with "Username:" and "Password:", but the wording varies, so the order is relied on
+	switch a.step {
+	case 1:
+		return []byte(a.username), nil
+	case 2:
+		return []byte(a.password), nil
+	default:
+		return nil, fmt.Errorf("unexpected LOGIN challenge %q", fromServer)
+	}
+}
+
+// Start sends the username and the token as the initial response
+func (a *xoauth2Auth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if err := checkAuthConnection(server); err != nil {
+		return "", nil, err
+	}
+	return "XOAUTH2", []byte("user=" + a.username + "\x01auth=Bearer " + a.token + "\x01\x01"), nil
+}
+
+// Next answers the error challenge of a rejected token with an empty response, so the server reports the failure
+func (a *xoauth2Auth) Next(_ []byte, more bool) ([]byte, error) {
+	if more {
+		return []byte{}, nil
+	}
+	return nil, nil
+}
+
+// checkAuthConnection refuses to send credentials in plain text except to localhost, like smtp.PlainAuth
+func checkAuthConnection(server *smtp.ServerInfo) error {
+	if server.TLS {
+		return nil
+	}
+	switch server.Name {
+	case "localhost", "127.0.0.1", "::1":
+		return nil
+	}
+	return errors.New("unencrypted connection")
+}
@@ -0,0 +1,168 @@
+package channels
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/logger"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+)
+
+// emailSparklineLength is the number of recent checks shown in the sparkline of an endpoint
+const emailSparklineLength = 20
+
+// Colours of the check results in the sparkline, matching the colours of the status page
+var emailSparklineColors = map[chk_result.CheckResult]string{
+	chk_result.ALL:         "#2ecc40",
+	chk_result.DEGRADED:    "#a0d911",
+	chk_result.PART:        "#ffb700",
+	chk_result.NONE:        "#ff4136",
+	chk_result.MAINTENANCE: "#5b8def",
+}
+
+type (
+	// emailSparklineCell is a check of the sparkline of an endpoint
+	emailSparklineCell struct {
+		Color string
+		Title string
+	}
+
+	// emailRow is an endpoint of a service table
+	emailRow struct {
+		URL        string
+		IsLink     bool
+		Method     string
+		StatusCode int
+		Attempts   string
+		LastError  string
+		Notes      []string
+		// CertBadge is the certificate problem of an endpoint, only set in certificate tables
+		CertBadge string
+		CertColor string
+		Sparkline []emailSparklineCell
+	}
+
+	// emailTable is the table of a section of a notification
+	emailTable struct {
+		Title            string
+		Color            string
+		IsCert           bool
+		Rows             []emailRow
+		ImpactedServices string
+	}
+)
+
+// emailHTMLTemplate renders the HTML part of notification emails. Mail clients such as Outlook ignore most of CSS,
+// so the layout only uses tables with inline styles.
+var emailHTMLTemplate = template.Must(template.New("email").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="UTF-8"><title>{{.Title}}</title></head>
+<body style="margin:0;padding:16px;font-family:'Segoe UI',Arial,sans-serif;font-size:14px;color:#222222;background:#f7fafd">
+<h2 style="margin:0 0 4px 0">{{.Title}}</h2>
+<p style="margin:0 0 16px 0;color:#666666">Generated at {{.GeneratedAt}}{{if .StatusPageURL}} · <a href="{{.StatusPageURL}}" style="color:#0077cc">View status page</a>{{end}}</p>
+{{- range .Tables}}
+<table role="presentation" width="100%" cellpadding="6" cellspacing="0" border="0" style="border-collapse:collapse;margin:0 0 16px 0;background:#ffffff;border:1px solid #e0e0e0">
+<tr><td colspan="{{if .IsCert}}2{{else}}{{if $.ShowSparkline}}6{{else}}5{{end}}{{end}}" style="background:{{.Color}};color:#ffffff;font-weight:bold;font-size:15px">{{.Title}}</td></tr>
+{{- if .IsCert}}
+<tr style="background:#f0f4fa;text-align:left"><th>URL</th><th>Certificate</th></tr>
+{{- range .Rows}}
+<tr style="border-top:1px solid #e0e0e0"><td>{{template "url" .}}</td><td><span style="display:inline-block;padding:2px 8px;border-radius:10px;color:#ffffff;background:{{.CertColor}}">{{.CertBadge}}</span></td></tr>
+{{- end}}
+{{- else}}
+<tr style="background:#f0f4fa;text-align:left"><th>URL</th><th>Method</th><th>Status code</th><th>Attempts</th><th>Last error</th>{{if $.ShowSparkline}}<th>Recent checks</th>{{end}}</tr>
+{{- range .Rows}}
+<tr style="border-top:1px solid #e0e0e0;vertical-align:top">
+<td>{{template "url" .}}{{range .Notes}}<br><span style="color:#666666;font-size:12px">{{.}}</span>{{end}}</td>
+<td>{{if .Method}}{{.Method}}{{else}}—{{end}}</td>
+<td>{{if .StatusCode}}{{.StatusCode}}{{else}}—{{end}}</td>
+<td>{{.Attempts}}</td>
+<td style="font-family:Consolas,monospace;font-size:12px">{{if .LastError}}{{.LastError}}{{else}}—{{end}}</td>
+{{- if $.ShowSparkline}}
+<td><table role="presentation" cellpadding="0" cellspacing="1" border="0"><tr>{{range .Sparkline}}<td width="4" height="14" title="{{.Title}}" style="width:4px;height:14px;background:{{.Color}}"></td>{{else}}<td style="color:#666666;font-size:12px">No history</td>{{end}}</tr></table></td>
+{{- end}}
+</tr>
+{{- end}}
+{{- end}}
+{{- if .ImpactedServices}}
+<tr style="border-top:1px solid #e0e0e0"><td colspan="6">⛓️ Impacted services: {{.ImpactedServices}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+<p style="margin:0;color:#666666;font-size:12px">{{.Summary}}</p>
+</body>
+</html>
+{{define "url"}}{{if .IsLink}}<a href="{{.URL}}" style="color:#0077cc">{{.URL}}</a>{{else}}<code>{{.URL}}</code>{{end}}{{end}}
+`))
+
+// buildEmailHTML renders the HTML part of a notification email with a table per service and incident type
+func buildEmailHTML(notification notifierTypes.Notification, showSparkline bool) (string, error) {
+	var tables []emailTable
+	for _, section := range buildSections(notification) {
+		table := emailTable{
+			Title:  fmt.Sprintf("%s %s %s", section.Emoji(), section.ServiceName, section.Summary),
+			Color:  section.Color(),
+			IsCert: section.Kind == sectionCert || section.Kind == sectionCertError,
+		}
+		for _, item := range section.Items {
+			table.Rows = append(table.Rows, newEmailRow(section, item, notification.History, showSparkline))
+		}
+		if len(section.ImpactedServices) > 0 {
+			table.ImpactedServices = strings.Join(section.ImpactedServices, ", ")
+		}
+		tables = append(tables, table)
+	}
+
+	var html bytes.Buffer
+	err := emailHTMLTemplate.Execute(&html, map[string]any{
+		"Title":         notification.Title,
+		"GeneratedAt":   notification.GeneratedAt.Format("2006-01-02 15:04:05"),
+		"StatusPageURL": notification.StatusPageURL,
+		"Tables":        tables,
+		"ShowSparkline": showSparkline,
+		"Summary":       summarizeNotification(notification),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to render HTML email: %w", err)
+	}
+	return html.String(), nil
+}
+
+// newEmailRow creates the table row of an endpoint of a section
+func newEmailRow(section notificationSection, item sectionItem, history logger.Logger, showSparkline bool) emailRow {
+	endpoint := item.Endpoint
+	row := emailRow{
+		URL:        item.URL,
+		IsLink:     isHTTPURL(item.URL),
+		Method:     endpoint.Method,
+		StatusCode: endpoint.StatusCode,
+		Attempts:   fmt.Sprintf("%d/%d", endpoint.SuccessNum, endpoint.AttemptNum),
+		Notes:      item.Notes,
+	}
+	if len(endpoint.FailureDetails) > 0 {
+		row.LastError = endpoint.FailureDetails[len(endpoint.FailureDetails)-1]
+	}
+	if section.Kind == sectionCert || section.Kind == sectionCertError {
+		row.CertBadge, row.CertColor = item.Details[0], section.Color()
+	}
+	if showSparkline {
+		row.Sparkline = buildEmailSparkline(history[section.ServiceName].Endpoints[item.URL])
+	}
+	return row
+}
+
+// buildEmailSparkline converts the most recent checks of an endpoint into coloured cells
+func buildEmailSparkline(history logger.History) []emailSparklineCell {
+	history = history[max(0, len(history)-emailSparklineLength):]
+	cells := make([]emailSparklineCell, 0, len(history))
+	for _, entry := range history {
+		color, ok := emailSparklineColors[chk_result.ParseCheckResult(entry.Status)]
+		if !ok {
+			color = "#e0e0e0"
+		}
+		cells = append(cells, emailSparklineCell{Color: color, Title: entry.Time + " " + entry.Status})
+	}
+	return cells
+}
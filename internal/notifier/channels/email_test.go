@@ -1,14 +1,30 @@
 package channels
 
 import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/smtp"
 	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
 	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/logger"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
 )
 
 func TestNewEmailNotifier(t *testing.T) {
@@ -111,6 +127,105 @@ func TestEmailNotifier_FormatRecipients(t *testing.T) {
 	}
 }
 
+func TestEmailNotifier_BuildMultipartEmailBody(t *testing.T) {
+	notifier := NewEmailNotifier(&configure.EmailConfig{From: "sender@example.com", To: []string{"recipient@example.com"}})
+	body, err := notifier.buildMultipartEmailBody("🚨 PongHub Service Status Alert", "🔴 Gateway is down", "<p>Gateway is down</p>")
+	if err != nil {
+		t.Fatalf("buildMultipartEmailBody failed: %v", err)
+	}
+
+	message, err := mail.ReadMessage(strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to parse email: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+	if err != nil || subject != "🚨 PongHub Service Status Alert" {
+		t.Errorf("Expected the encoded subject to decode to the title, got %q (%v)", subject, err)
+	}
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Expected a multipart/alternative email, got %q", message.Header.Get("Content-Type"))
+	}
+
+	var contentTypes, contents []string
+	reader := multipart.NewReader(message.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Failed to read email part: %v", err)
+		}
+		// the multipart reader decodes quoted-printable parts itself
+		content, _ := io.ReadAll(part)
+		contentTypes = append(contentTypes, part.Header.Get("Content-Type"))
+		contents = append(contents, string(content))
+	}
+
+	expectedTypes := []string{"text/plain; charset=UTF-8", "text/html; charset=UTF-8"}
+	if !slices.Equal(contentTypes, expectedTypes) {
+		t.Fatalf("Expected the parts %v, got %v", expectedTypes, contentTypes)
+	}
+	if contents[0] != "🔴 Gateway is down" || contents[1] != "<p>Gateway is down</p>" {
+		t.Errorf("Expected the plain text and HTML versions, got %q", contents)
+	}
+}
+
+func TestBuildEmailHTML(t *testing.T) {
+	notification := notifierTypes.Notification{
+		Title: "🚨 PongHub Service Status Alert",
+		Events: []notifierTypes.Event{{
+			ServiceName: "Gateway",
+			Endpoint: checker.Endpoint{
+				URL:            "https://gateway.example.com/health",
+				Method:         "GET",
+				StatusCode:     502,
+				AttemptNum:     3,
+				FailureDetails: []string{"status code 502 not in <200>"},
+			},
+			Status: "down", PreviousStatus: "up",
+		}},
+		CertProblems: map[string][]checker.Endpoint{
+			"Website": {{URL: "https://example.com", IsCertExpired: true}},
+		},
+		History: logger.Logger{
+			"Gateway": {Endpoints: logger.Endpoints{"https://gateway.example.com/health": {
+				{Time: "2025-01-01T09:00:00Z", Status: "all"},
+				{Time: "2025-01-01T09:30:00Z", Status: "none"},
+			}}},
+		},
+		GeneratedAt: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	html, err := buildEmailHTML(notification, true)
+	if err != nil {
+		t.Fatalf("buildEmailHTML failed: %v", err)
+	}
+	for _, expected := range []string{
+		"🔴 Gateway is down",
+		`<a href="https://gateway.example.com/health"`,
+		"<td>GET</td>",
+		"<td>502</td>",
+		"<td>0/3</td>",
+		"status code 502 not in &lt;200&gt;",
+		`title="2025-01-01T09:30:00Z none" style="width:4px;height:14px;background:#ff4136"`,
+		"background:#ff4136\">❌ Certificate expired</span>",
+	} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("Expected the HTML to contain %q, got:\n%s", expected, html)
+		}
+	}
+
+	html, err = buildEmailHTML(notification, false)
+	if err != nil {
+		t.Fatalf("buildEmailHTML failed: %v", err)
+	}
+	if strings.Contains(html, "Recent checks") {
+		t.Error("Expected no sparkline unless it is enabled")
+	}
+}
+
 // Test for missing credentials
 func TestEmailNotifier_Send_MissingCredentials(t *testing.T) {
 	// Temporarily clear environment variables
@@ -258,6 +373,248 @@ func TestEmailNotifier_Send_InvalidServer(t *testing.T) {
 	}
 }
 
+// fakeSMTPChallenge is the CRAM-MD5 challenge of the fake SMTP server
+const fakeSMTPChallenge = "<1896.697170952@localhost>"
+
+// fakeSMTPSession records what a client sent to the fake SMTP server
+type fakeSMTPSession struct {
+	mechanism string
+	// credentials are the decoded authentication responses of the client
+	credentials []string
+	from        string
+	to          []string
+	data        string
+}
+
+// startFakeSMTPServer starts an SMTP server on localhost that accepts one session with any credentials,
+// advertising the given auth mechanisms, and returns its port and the recorded session
+func startFakeSMTPServer(t *testing.T, mechanisms string) (int, <-chan fakeSMTPSession) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to start fake SMTP server: %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	sessions := make(chan fakeSMTPSession, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		sessions <- serveFakeSMTP(conn, mechanisms)
+	}()
+	return listener.Addr().(*net.TCPAddr).Port, sessions
+}
+
+// serveFakeSMTP speaks just enough SMTP for net/smtp to authenticate and send a message
+func serveFakeSMTP(conn net.Conn, mechanisms string) fakeSMTPSession {
+	var session fakeSMTPSession
+	reader := bufio.NewReader(conn)
+	reply := func(lines ...string) {
+		for _, line := range lines {
+			_, _ = fmt.Fprintf(conn, "%s\r\n", line)
+		}
+	}
+	readLine := func() (string, bool) {
+		line, err := reader.ReadString('\n')
+		return strings.TrimRight(line, "\r\n"), err == nil
+	}
+	readCredential := func() {
+		line, _ := readLine()
+		decoded, _ := base64.StdEncoding.DecodeString(line)
+		session.credentials = append(session.credentials, string(decoded))
+	}
+	encode := base64.StdEncoding.EncodeToString
+
+	reply("220 localhost ESMTP fake")
+	for {
+		line, ok := readLine()
+		if !ok {
+			return session
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			if mechanisms == "" {
+				reply("250 localhost")
+			} else {
+				reply("250-localhost", "250 AUTH "+mechanisms)
+			}
+		case "AUTH":
+			mechanism, initial, _ := strings.Cut(arg, " ")
+			session.mechanism = mechanism
+			switch mechanism {
+			case "LOGIN":
+				reply("334 " + encode([]byte("Username:")))
+				readCredential()
+				reply("334 " + encode([]byte("Password:")))
+				readCredential()
+			case "CRAM-MD5":
+				reply("334 " + encode([]byte(fakeSMTPChallenge)))
+				readCredential()
+			default:
+				decoded, _ := base64.StdEncoding.DecodeString(initial)
+				session.credentials = append(session.credentials, string(decoded))
+			}
+			reply("235 2.7.0 Authentication successful")
+		case "MAIL":
+			session.from = arg
+			reply("250 OK")
+		case "RCPT":
+			session.to = append(session.to, arg)
+			reply("250 OK")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				line, ok := readLine()
+				if !ok || line == "." {
+					break
+				}
+				data.WriteString(line + "\n")
+			}
+			session.data = data.String()
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			return session
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func TestEmailNotifier_Send_AuthMechanisms(t *testing.T) {
+	t.Setenv("SMTP_USERNAME", "")
+	t.Setenv("SMTP_PASSWORD", "")
+	t.Setenv("SMTP_OAUTH_TOKEN", "")
+	t.Setenv("PONGHUB_TEST_SMTP_PASSWORD", "secret")
+
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(passwordFile, []byte("secret\n"), 0600); err != nil {
+		t.Fatalf("Failed to write password file: %v", err)
+	}
+	mac := hmac.New(md5.New, []byte("secret"))
+	mac.Write([]byte(fakeSMTPChallenge))
+
+	tests := []struct {
+		name        string
+		mechanisms  string
+		config      configure.EmailConfig
+		mechanism   string
+		credentials []string
+	}{
+		{
+			name:   "No auth on a relay",
+			config: configure.EmailConfig{Auth: "none"},
+		},
+		{
+			name:        "PLAIN with password from env parameter",
+			mechanisms:  "PLAIN LOGIN",
+			config:      configure.EmailConfig{Username: "alice", Password: "{{env(PONGHUB_TEST_SMTP_PASSWORD)}}"},
+			mechanism:   "PLAIN",
+			credentials: []string{"\x00alice\x00secret"},
+		},
+		{
+			name:        "LOGIN with password file",
+			mechanisms:  "LOGIN",
+			config:      configure.EmailConfig{Auth: "login", Username: "alice", PasswordFile: passwordFile},
+			mechanism:   "LOGIN",
+			credentials: []string{"alice", "secret"},
+		},
+		{
+			name:        "CRAM-MD5",
+			mechanisms:  "CRAM-MD5",
+			config:      configure.EmailConfig{Auth: "CRAM-MD5", Username: "alice", Password: "secret"},
+			mechanism:   "CRAM-MD5",
+			credentials: []string{"alice " + hex.EncodeToString(mac.Sum(nil))},
+		},
+		{
+			name:        "XOAUTH2",
+			mechanisms:  "XOAUTH2",
+			config:      configure.EmailConfig{Auth: "xoauth2", Username: "alice@example.com", Token: "ya29.token"},
+			mechanism:   "XOAUTH2",
+			credentials: []string{"user=alice@example.com\x01auth=Bearer ya29.token\x01\x01"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			port, sessions := startFakeSMTPServer(t, tt.mechanisms)
+			config := tt.config
+			config.SMTPHost = "127.0.0.1"
+			config.SMTPPort = port
+			config.From = "ponghub@example.com"
+			config.To = []string{"ops@example.com"}
+
+			if err := NewEmailNotifier(&config).Send("Service down", "Gateway is down"); err != nil {
+				t.Fatalf("Send failed: %v", err)
+			}
+
+			var session fakeSMTPSession
+			select {
+			case session = <-sessions:
+			case <-time.After(5 * time.Second):
+				t.Fatal("Fake SMTP server did not finish the session")
+			}
+			if session.mechanism != tt.mechanism {
+				t.Errorf("Expected mechanism %q, got %q", tt.mechanism, session.mechanism)
+			}
+			if !slices.Equal(session.credentials, tt.credentials) {
+				t.Errorf("Expected credentials %q, got %q", tt.credentials, session.credentials)
+			}
+			if session.from != "FROM:<ponghub@example.com>" || !slices.Equal(session.to, []string{"TO:<ops@example.com>"}) {
+				t.Errorf("Unexpected envelope: from %q, to %q", session.from, session.to)
+			}
+			if !strings.Contains(session.data, "Gateway is down") {
+				t.Errorf("Expected the message in the data, got %q", session.data)
+			}
+		})
+	}
+}
+
+func TestEmailNotifier_NewSMTPAuth_Errors(t *testing.T) {
+	t.Setenv("SMTP_USERNAME", "alice")
+	t.Setenv("SMTP_PASSWORD", "")
+	t.Setenv("SMTP_OAUTH_TOKEN", "")
+
+	tests := []struct {
+		name    string
+		config  configure.EmailConfig
+		message string
+	}{
+		{"Unknown mechanism", configure.EmailConfig{Auth: "digest-md5"}, `unsupported SMTP auth "digest-md5"`},
+		{"Missing password", configure.EmailConfig{Auth: "login"}, "SMTP credentials not found"},
+		{"Missing token", configure.EmailConfig{Auth: "xoauth2", Password: "secret"}, "xoauth2 needs a username and a token"},
+		{"Missing credential file", configure.EmailConfig{PasswordFile: filepath.Join(t.TempDir(), "missing")}, "failed to read SMTP credential file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewEmailNotifier(&tt.config).newSMTPAuth()
+			if err == nil || !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("Expected error containing %q, got %v", tt.message, err)
+			}
+		})
+	}
+}
+
+func TestSMTPAuth_RefusesUnencryptedConnection(t *testing.T) {
+	auths := []smtp.Auth{
+		&loginAuth{username: "alice", password: "secret"},
+		&xoauth2Auth{username: "alice", token: "token"},
+	}
+	for _, auth := range auths {
+		if _, _, err := auth.Start(&smtp.ServerInfo{Name: "smtp.example.com"}); err == nil {
+			t.Errorf("Expected %T to refuse an unencrypted connection to a remote server", auth)
+		}
+		if _, _, err := auth.Start(&smtp.ServerInfo{Name: "smtp.example.com", TLS: true}); err != nil {
+			t.Errorf("Expected %T to accept a TLS connection, got %v", auth, err)
+		}
+	}
+}
+
 // Benchmark test
 func BenchmarkEmailNotifier_BuildEmailBody(b *testing.B) {
 	config := &configure.EmailConfig{
@@ -0,0 +1,124 @@
+package channels
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/wcy-dt/ponghub/internal/common/params"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/alert_status"
+)
+
+// feishuMaxTextBytes keeps messages below the 30 KB request limit of Feishu robots
+const feishuMaxTextBytes = 20000
+
+type (
+	// feishuElement is an element of a Feishu message card, its fields depend on the tag
+	feishuElement map[string]any
+
+	// feishuCard is a Feishu message card
+	feishuCard struct {
+		Header   feishuElement   `json:"header"`
+		Elements []feishuElement `json:"elements"`
+	}
+
+	// feishuMessage is the payload posted to a Feishu robot, signed requests carry the timestamp and signature
+	feishuMessage struct {
+		Timestamp string        `json:"timestamp,omitempty"`
+		Sign      string        `json:"sign,omitempty"`
+		MsgType   string        `json:"msg_type"`
+		Content   feishuElement `json:"content,omitempty"`
+		Card      *feishuCard   `json:"card,omitempty"`
+	}
+)
+
+// FeishuNotifier implements Feishu and Lark group robot notifications
+type FeishuNotifier struct {
+	config *configure.FeishuConfig
+}
+
+// NewFeishuNotifier creates a new Feishu notifier
+func NewFeishuNotifier(config *configure.FeishuConfig) *FeishuNotifier {
+	return &FeishuNotifier{config: config}
+}
+
+// Send sends a plain text message, split into several messages if it is too long
+func (f *FeishuNotifier) Send(title, message string) error {
+	var messages []feishuMessage
+	for _, chunk := range splitTextBy(title+"\n\n"+message, feishuMaxTextBytes, utf8.RuneLen) {
+		messages = append(messages, feishuMessage{MsgType: "text", Content: feishuElement{"text": chunk}})
+	}
+	return f.postAll(messages)
+}
+
+// SendNotification sends a message card with a colour-coded header and one markdown block per service and incident type
+func (f *FeishuNotifier) SendNotification(notification notifierTypes.Notification) error {
+	header := feishuElement{
+		"title":    feishuElement{"tag": "plain_text", "content": notification.Title},
+		"template": feishuHeaderTemplate(notification),
+	}
+
+	var messages []feishuMessage
+	for _, chunk := range splitTextBy(formatMarkdownSections(notification, "\n"), feishuMaxTextBytes, utf8.RuneLen) {
+		messages = append(messages, feishuMessage{
+			MsgType: "interactive",
+			Card: &feishuCard{
+				Header:   header,
+				Elements: []feishuElement{{"tag": "markdown", "content": chunk}},
+			},
+		})
+	}
+	return f.postAll(messages)
+}
+
+// postAll posts the messages in order to the configured robot, signing every message if a secret is configured
+func (f *FeishuNotifier) postAll(messages []feishuMessage) error {
+	resolver := params.NewParameterResolver()
+	webhookURL := f.config.WebhookURL
+	if webhookURL == "" {
+		webhookURL = os.Getenv("FEISHU_WEBHOOK_URL")
+	}
+	if webhookURL == "" {
+		return fmt.Errorf("feishu webhook URL not configured")
+	}
+	webhookURL = resolver.ResolveParameters(webhookURL)
+
+	secret := resolver.ResolveParameters(f.config.Secret)
+	if secret == "" {
+		secret = os.Getenv("FEISHU_SECRET")
+	}
+
+	for i, message := range messages {
+		if secret != "" {
+			// Feishu signs timestamps in seconds
+			timestamp := time.Now().Unix()
+			message.Timestamp = strconv.FormatInt(timestamp, 10)
+			message.Sign = signRobotRequest(timestamp, secret, true)
+		}
+		if err := sendRobotMessage(webhookURL, message, f.config.Retries, f.config.Timeout); err != nil {
+			return fmt.Errorf("failed to send message %d of %d: %w", i+1, len(messages), err)
+		}
+	}
+	return nil
+}
+
+// feishuHeaderTemplate returns the colour of the card header for the most severe problem of the notification
+func feishuHeaderTemplate(notification notifierTypes.Notification) string {
+	template := "green"
+	if len(notification.CertProblems) > 0 {
+		template = "yellow"
+	}
+	for _, event := range notification.Events {
+		switch {
+		case event.Status == alert_status.DOWN && !event.IsImpacted():
+			return "red"
+		case event.Status != alert_status.UP:
+			template = "orange"
+		}
+	}
+	return template
+}
@@ -0,0 +1,93 @@
+package channels
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+func TestSignRobotRequest_Feishu(t *testing.T) {
+	if sign := signRobotRequest(1700000000, "SEC000", true); sign != "QKhXycVUGrhA1dUEbXA2Vo/zGkv/W88IgYgKNA7Xmk0=" {
+		t.Errorf("Unexpected signature %s", sign)
+	}
+}
+
+func TestFeishuNotifier_SendNotification(t *testing.T) {
+	var messages []feishuMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message feishuMessage
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Errorf("Failed to decode Feishu message: %v", err)
+		}
+		messages = append(messages, message)
+		_, _ = w.Write([]byte(`{"code":0,"msg":"success"}`))
+	}))
+	defer server.Close()
+
+	notification := notifierTypes.Notification{
+		Title: "🚨 PongHub Service Status Alert",
+		Events: []notifierTypes.Event{
+			{ServiceName: "Cache", Endpoint: checker.Endpoint{URL: "cache.example.com:6379"}, Status: "degraded", PreviousStatus: "up"},
+		},
+	}
+	notifier := NewFeishuNotifier(&configure.FeishuConfig{WebhookURL: server.URL, Secret: "SEC000"})
+	if err := notifier.SendNotification(notification); err != nil {
+		t.Fatalf("SendNotification failed: %v", err)
+	}
+
+	if len(messages) != 1 || messages[0].MsgType != "interactive" || messages[0].Card == nil {
+		t.Fatalf("Expected a message card, got %+v", messages)
+	}
+	message := messages[0]
+	timestamp, err := strconv.ParseInt(message.Timestamp, 10, 64)
+	if err != nil || message.Sign != signRobotRequest(timestamp, "SEC000", true) {
+		t.Errorf("Expected the message to be signed, got timestamp %q and sign %q", message.Timestamp, message.Sign)
+	}
+	if message.Card.Header["template"] != "orange" {
+		t.Errorf("Expected an orange header for a degraded service, got %v", message.Card.Header["template"])
+	}
+	content, _ := message.Card.Elements[0]["content"].(string)
+	if !strings.Contains(content, "**🐢 Cache is degraded**") || !strings.Contains(content, "`cache.example.com:6379`") {
+		t.Errorf("Expected the degraded service in the markdown, got:\n%s", content)
+	}
+}
+
+func TestFeishuHeaderTemplate(t *testing.T) {
+	tests := []struct {
+		name         string
+		notification notifierTypes.Notification
+		expected     string
+	}{
+		{"down", notifierTypes.Notification{Events: []notifierTypes.Event{{Status: "up"}, {Status: "down"}}}, "red"},
+		{"impacted", notifierTypes.Notification{Events: []notifierTypes.Event{{Status: "down", ImpactedBy: "Gateway"}}}, "orange"},
+		{"recovered", notifierTypes.Notification{Events: []notifierTypes.Event{{Status: "up"}}}, "green"},
+		{"cert", notifierTypes.Notification{CertProblems: map[string][]checker.Endpoint{"Website": {{}}}}, "yellow"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if template := feishuHeaderTemplate(tt.notification); template != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, template)
+			}
+		})
+	}
+}
+
+func TestFeishuNotifier_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"code":19021,"msg":"sign match fail or timestamp is not within one hour from current time"}`))
+	}))
+	defer server.Close()
+
+	notifier := NewFeishuNotifier(&configure.FeishuConfig{WebhookURL: server.URL, Secret: "wrong"})
+	if err := notifier.Send("Title", "Message"); err == nil || !strings.Contains(err.Error(), "19021") {
+		t.Errorf("Expected the error of the robot, got %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package channels
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/wcy-dt/ponghub/internal/common/params"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+// Gotify priorities of the push priorities, the Android app pops up messages from 8, plays a sound from 4
+// and shows messages silently from 1
+var gotifyPriorities = map[pushPriority]int{
+	priorityMin:     0,
+	priorityLow:     2,
+	priorityDefault: 5,
+	priorityHigh:    8,
+	priorityUrgent:  10,
+}
+
+// gotifyMessage is the payload posted to the message API of a Gotify server
+type gotifyMessage struct {
+	Title    string         `json:"title,omitempty"`
+	Message  string         `json:"message"`
+	Priority int            `json:"priority"`
+	Extras   map[string]any `json:"extras,omitempty"`
+}
+
+// GotifyNotifier implements Gotify push notifications
+type GotifyNotifier struct {
+	config *configure.GotifyConfig
+}
+
+// NewGotifyNotifier creates a new Gotify notifier
+func NewGotifyNotifier(config *configure.GotifyConfig) *GotifyNotifier {
+	return &GotifyNotifier{config: config}
+}
+
+// Send sends a message with the default priority
+func (g *GotifyNotifier) Send(title, message string) error {
+	return g.post(gotifyMessage{Title: title, Message: message, Priority: gotifyPriorities[priorityDefault]})
+}
+
+// SendNotification sends a message with the priority of the problems of the notification, opening the status page on click
+func (g *GotifyNotifier) SendNotification(notification notifierTypes.Notification) error {
+	message := gotifyMessage{
+		Title:    notification.Title,
+		Message:  notification.Message,
+		Priority: gotifyPriorities[notificationPriority(notification)],
+		Extras: map[string]any{
+			"client::display": map[string]string{"contentType": "text/plain"},
+		},
+	}
+	if notification.StatusPageURL != "" {
+		message.Extras["client::notification"] = map[string]any{"click": map[string]string{"url": notification.StatusPageURL}}
+	}
+	return g.post(message)
+}
+
+// post posts the message to the configured server with the application token
+func (g *GotifyNotifier) post(message gotifyMessage) error {
+	resolver := params.NewParameterResolver()
+	if g.config.ServerURL == "" {
+		return fmt.Errorf("gotify server URL not configured")
+	}
+
+	token := resolver.ResolveParameters(g.config.Token)
+	if token == "" {
+		token = os.Getenv("GOTIFY_TOKEN")
+	}
+	if token == "" {
+		return fmt.Errorf("gotify application token not configured")
+	}
+
+	url := strings.TrimSuffix(resolver.ResolveParameters(g.config.ServerURL), "/") + "/message"
+	return sendHTTPRequest(url, "POST", message, map[string]string{"X-Gotify-Key": token}, g.config.Retries, g.config.Timeout, false)
+}
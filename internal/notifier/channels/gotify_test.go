@@ -0,0 +1,61 @@
+package channels
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+func TestGotifyNotifier_SendNotification(t *testing.T) {
+	var messages []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/message" || r.Header.Get("X-Gotify-Key") != "app-token" {
+			t.Errorf("Expected an authenticated request to the message API, got %s", r.URL.Path)
+		}
+		var message map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Errorf("Failed to decode Gotify message: %v", err)
+		}
+		messages = append(messages, message)
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	notification := notifierTypes.Notification{
+		Title:         "🚨 PongHub Service Status Alert",
+		Message:       "Certificate expires in 5 days",
+		CertProblems:  map[string][]checker.Endpoint{"Website": {{URL: "https://example.com", CertRemainingDays: 5}}},
+		StatusPageURL: "https://status.example.com",
+	}
+	notifier := NewGotifyNotifier(&configure.GotifyConfig{ServerURL: server.URL, Token: "app-token"})
+	if err := notifier.SendNotification(notification); err != nil {
+		t.Fatalf("SendNotification failed: %v", err)
+	}
+
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	message := messages[0]
+	if message["title"] != notification.Title || message["priority"] != float64(2) {
+		t.Errorf("Expected a low priority message, got %+v", message)
+	}
+	extras, _ := message["extras"].(map[string]any)
+	clientNotification, _ := extras["client::notification"].(map[string]any)
+	click, _ := clientNotification["click"].(map[string]any)
+	if click["url"] != notification.StatusPageURL {
+		t.Errorf("Expected the message to open the status page, got %+v", extras)
+	}
+}
+
+func TestGotifyNotifier_MissingToken(t *testing.T) {
+	t.Setenv("GOTIFY_TOKEN", "")
+	notifier := NewGotifyNotifier(&configure.GotifyConfig{ServerURL: "https://gotify.example.com"})
+	if err := notifier.Send("Title", "Message"); err == nil {
+		t.Error("Expected an error without an application token")
+	}
+}
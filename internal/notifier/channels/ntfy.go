@@ -0,0 +1,77 @@
+package channels
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/wcy-dt/ponghub/internal/common/params"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+// ntfyServerURL is the default ntfy server
+const ntfyServerURL = "https://ntfy.sh"
+
+// ntfyMessage is the JSON payload published to an ntfy server
+type ntfyMessage struct {
+	Topic    string       `json:"topic"`
+	Title    string       `json:"title,omitempty"`
+	Message  string       `json:"message"`
+	Priority pushPriority `json:"priority,omitempty"`
+	Tags     []string     `json:"tags,omitempty"`
+	Click    string       `json:"click,omitempty"`
+}
+
+// NtfyNotifier implements ntfy push notifications
+type NtfyNotifier struct {
+	config *configure.NtfyConfig
+}
+
+// NewNtfyNotifier creates a new ntfy notifier
+func NewNtfyNotifier(config *configure.NtfyConfig) *NtfyNotifier {
+	return &NtfyNotifier{config: config}
+}
+
+// Send publishes a notification with the default priority
+func (n *NtfyNotifier) Send(title, message string) error {
+	return n.publish(ntfyMessage{Title: title, Message: message, Priority: priorityDefault})
+}
+
+// SendNotification publishes a notification with the priority and tags of its problems, opening the status page on click
+func (n *NtfyNotifier) SendNotification(notification notifierTypes.Notification) error {
+	return n.publish(ntfyMessage{
+		Title:    notification.Title,
+		Message:  notification.Message,
+		Priority: notificationPriority(notification),
+		Tags:     notificationTags(notification),
+		Click:    notification.StatusPageURL,
+	})
+}
+
+// publish publishes the message to the configured topic, authenticating with the access token if one is configured
+func (n *NtfyNotifier) publish(message ntfyMessage) error {
+	resolver := params.NewParameterResolver()
+	if n.config.Topic == "" {
+		return fmt.Errorf("ntfy topic not configured")
+	}
+	message.Topic = resolver.ResolveParameters(n.config.Topic)
+	message.Tags = append(message.Tags, n.config.Tags...)
+
+	serverURL := n.config.ServerURL
+	if serverURL == "" {
+		serverURL = ntfyServerURL
+	}
+
+	token := resolver.ResolveParameters(n.config.Token)
+	if token == "" {
+		token = os.Getenv("NTFY_TOKEN")
+	}
+	headers := make(map[string]string)
+	if token != "" {
+		headers["Authorization"] = "Bearer " + token
+	}
+
+	// publishing JSON goes to the root of the server, the topic is part of the payload
+	return sendHTTPRequest(strings.TrimSuffix(resolver.ResolveParameters(serverURL), "/")+"/", "POST", message, headers, n.config.Retries, n.config.Timeout, false)
+}
@@ -0,0 +1,66 @@
+package channels
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+func TestNtfyNotifier_SendNotification(t *testing.T) {
+	var messages []ntfyMessage
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			t.Errorf("Expected JSON to be published to the root, got %s", r.URL.Path)
+		}
+		authorization = r.Header.Get("Authorization")
+		var message ntfyMessage
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Errorf("Failed to decode ntfy message: %v", err)
+		}
+		messages = append(messages, message)
+	}))
+	defer server.Close()
+	t.Setenv("NTFY_TOKEN", "tk_secret")
+
+	notification := notifierTypes.Notification{
+		Title:         "🚨 PongHub Service Status Alert",
+		Message:       "Gateway is down",
+		Events:        []notifierTypes.Event{{ServiceName: "Gateway", Endpoint: checker.Endpoint{URL: "https://gateway.example.com"}, Status: "down"}},
+		StatusPageURL: "https://status.example.com",
+	}
+	notifier := NewNtfyNotifier(&configure.NtfyConfig{ServerURL: server.URL + "/", Topic: "ponghub", Tags: []string{"homelab"}})
+	if err := notifier.SendNotification(notification); err != nil {
+		t.Fatalf("SendNotification failed: %v", err)
+	}
+
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	message := messages[0]
+	if message.Topic != "ponghub" || message.Title != notification.Title || message.Message != notification.Message {
+		t.Errorf("Expected the notification on the configured topic, got %+v", message)
+	}
+	if message.Priority != priorityUrgent || message.Click != notification.StatusPageURL {
+		t.Errorf("Expected an urgent message opening the status page, got %+v", message)
+	}
+	if !slices.Equal(message.Tags, []string{"rotating_light", "homelab"}) {
+		t.Errorf("Expected the problem and configured tags, got %v", message.Tags)
+	}
+	if authorization != "Bearer tk_secret" {
+		t.Errorf("Expected the access token from the environment, got %q", authorization)
+	}
+}
+
+func TestNtfyNotifier_MissingTopic(t *testing.T) {
+	notifier := NewNtfyNotifier(&configure.NtfyConfig{})
+	if err := notifier.Send("Title", "Message"); err == nil {
+		t.Error("Expected an error without a topic")
+	}
+}
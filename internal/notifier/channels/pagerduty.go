@@ -0,0 +1,240 @@
+package channels
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/common/params"
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/alert_status"
+	"github.com/wcy-dt/ponghub/internal/types/types/severity"
+)
+
+// pagerDutyEventsURL is the default endpoint of the PagerDuty Events API v2
+const pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// Event actions of the Events API
+const (
+	pagerDutyTrigger = "trigger"
+	pagerDutyResolve = "resolve"
+)
+
+type (
+	// pagerDutyPayload describes the problem of a trigger event
+	pagerDutyPayload struct {
+		Summary       string         `json:"summary"`
+		Source        string         `json:"source"`
+		Severity      string         `json:"severity"`
+		Timestamp     string         `json:"timestamp,omitempty"`
+		Component     string         `json:"component,omitempty"`
+		Class         string         `json:"class,omitempty"`
+		CustomDetails map[string]any `json:"custom_details,omitempty"`
+	}
+
+	// pagerDutyLink is a link attached to an incident
+	pagerDutyLink struct {
+		Href string `json:"href"`
+		Text string `json:"text"`
+	}
+
+	// pagerDutyEvent is a trigger or resolve event of the Events API
+	pagerDutyEvent struct {
+		RoutingKey  string            `json:"routing_key"`
+		EventAction string            `json:"event_action"`
+		DedupKey    string            `json:"dedup_key"`
+		Payload     *pagerDutyPayload `json:"payload,omitempty"`
+		Links       []pagerDutyLink   `json:"links,omitempty"`
+	}
+)
+
+// PagerDutyNotifier implements incident notifications through the PagerDuty Events API v2 or a compatible API
+type PagerDutyNotifier struct {
+	config *configure.PagerDutyConfig
+}
+
+// NewPagerDutyNotifier creates a new PagerDuty notifier
+func NewPagerDutyNotifier(config *configure.PagerDutyConfig) *PagerDutyNotifier {
+	return &PagerDutyNotifier{config: config}
+}
+
+// Send is not supported, incidents are only created from the events of a notification
+func (p *PagerDutyNotifier) Send(_, _ string) error {
+	return fmt.Errorf("pagerduty only supports notifications with events")
+}
+
+// SendNotification triggers an incident for every endpoint with a problem and resolves it when the endpoint recovers,
+// a failed event does not stop the others
+func (p *PagerDutyNotifier) SendNotification(notification notifierTypes.Notification) error {
+	return p.sendEvents(buildPagerDutyEvents(notification))
+}
+
+// ResolveIncidents resolves the incidents of the fixed certificates and of the removed endpoints of the notification
+func (p *PagerDutyNotifier) ResolveIncidents(notification notifierTypes.Notification) error {
+	return p.sendEvents(buildPagerDutyResolveEvents(notification))
+}
+
+// sendEvents sends the events with the configured routing key, a failed event does not stop the others
+func (p *PagerDutyNotifier) sendEvents(events []pagerDutyEvent) error {
+	routingKey := params.NewParameterResolver().ResolveParameters(p.config.RoutingKey)
+	if routingKey == "" {
+		routingKey = os.Getenv("PAGERDUTY_ROUTING_KEY")
+	}
+	if routingKey == "" {
+		return fmt.Errorf("pagerduty routing key not configured")
+	}
+
+	eventsURL := p.config.EventsURL
+	if eventsURL == "" {
+		eventsURL = pagerDutyEventsURL
+	}
+
+	var errs []error
+	for _, event := range events {
+		event.RoutingKey = routingKey
+		if err := sendHTTPRequest(eventsURL, "POST", event, nil, p.config.Retries, p.config.Timeout, false); err != nil {
+			errs = append(errs, fmt.Errorf("%s event %s: %w", event.EventAction, event.DedupKey, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// buildPagerDutyEvents converts the events and certificate problems of a notification into Events API events.
+// Reminders are left out, the incident is still open.
+func buildPagerDutyEvents(notification notifierTypes.Notification) []pagerDutyEvent {
+	var links []pagerDutyLink
+	if notification.StatusPageURL != "" {
+		links = []pagerDutyLink{{Href: notification.StatusPageURL, Text: "Status page"}}
+	}
+	timestamp := notification.GeneratedAt.Format(time.RFC3339)
+
+	var events []pagerDutyEvent
+	for _, event := range notification.Events {
+		if event.IsReminder {
+			continue
+		}
+		dedupKey := pagerDutyDedupKey(event.ServiceName, event.Endpoint.URL, "availability")
+		if event.IsRecovery() {
+			events = append(events, pagerDutyEvent{EventAction: pagerDutyResolve, DedupKey: dedupKey})
+			continue
+		}
+
+		payload := &pagerDutyPayload{
+			Summary:       fmt.Sprintf("%s is %s: %s", event.ServiceName, event.Status, event.Endpoint.URL),
+			Source:        pagerDutySource(event.Endpoint.URL),
+			Severity:      notification.EventSeverity(event).String(),
+			Timestamp:     timestamp,
+			Component:     event.ServiceName,
+			Class:         string(event.Status),
+			CustomDetails: pagerDutyDetails(event),
+		}
+		if event.IsImpacted() {
+			payload.Summary = fmt.Sprintf("%s is %s, impacted by %s: %s", event.ServiceName, event.Status, event.ImpactedBy, event.Endpoint.URL)
+		}
+		events = append(events, pagerDutyEvent{EventAction: pagerDutyTrigger, DedupKey: dedupKey, Payload: payload, Links: links})
+	}
+
+	// map order is random, so the certificate problems are sorted by service
+	certServiceNames := make([]string, 0, len(notification.CertProblems))
+	for serviceName := range notification.CertProblems {
+		certServiceNames = append(certServiceNames, serviceName)
+	}
+	slices.Sort(certServiceNames)
+	for _, serviceName := range certServiceNames {
+		for _, endpoint := range notification.CertProblems[serviceName] {
+			level := notification.CertSeverity(serviceName, endpoint)
+			events = append(events, newPagerDutyCertEvent(serviceName, endpoint, level, timestamp, links))
+		}
+	}
+	return events
+}
+
+// buildPagerDutyResolveEvents creates the resolve events of the fixed certificates and of the removed endpoints,
+// whose problems ended without a recovery event
+func buildPagerDutyResolveEvents(notification notifierTypes.Notification) []pagerDutyEvent {
+	var events []pagerDutyEvent
+	for _, serviceName := range slices.Sorted(maps.Keys(notification.CertRecoveries)) {
+		for _, endpoint := range notification.CertRecoveries[serviceName] {
+			events = append(events, pagerDutyEvent{EventAction: pagerDutyResolve, DedupKey: pagerDutyDedupKey(serviceName, endpoint.URL, "certificate")})
+		}
+	}
+	for _, serviceName := range slices.Sorted(maps.Keys(notification.Removed)) {
+		serviceState := notification.Removed[serviceName]
+		for _, endpointURL := range slices.Sorted(maps.Keys(serviceState)) {
+			if serviceState[endpointURL].IsAlerting() {
+				events = append(events, pagerDutyEvent{EventAction: pagerDutyResolve, DedupKey: pagerDutyDedupKey(serviceName, endpointURL, "availability")})
+			}
+			if serviceState[endpointURL].CertLastNotified != "" {
+				events = append(events, pagerDutyEvent{EventAction: pagerDutyResolve, DedupKey: pagerDutyDedupKey(serviceName, endpointURL, "certificate")})
+			}
+		}
+	}
+	return events
+}
+
+// newPagerDutyCertEvent creates the trigger event of an expired or expiring certificate
+func newPagerDutyCertEvent(serviceName string, endpoint checker.Endpoint, level severity.Severity, timestamp string, links []pagerDutyLink) pagerDutyEvent {
+	payload := &pagerDutyPayload{
+		Summary:       fmt.Sprintf("%s certificate expires in %d days: %s", serviceName, endpoint.CertRemainingDays, endpoint.URL),
+		Source:        pagerDutySource(endpoint.URL),
+		Severity:      level.String(),
+		Timestamp:     timestamp,
+		Component:     serviceName,
+		Class:         "certificate",
+		CustomDetails: map[string]any{"url": endpoint.URL, "cert_remaining_days": endpoint.CertRemainingDays},
+	}
+	if endpoint.IsCertExpired {
+		payload.Summary = fmt.Sprintf("%s certificate expired: %s", serviceName, endpoint.URL)
+	}
+	return pagerDutyEvent{
+		EventAction: pagerDutyTrigger,
+		DedupKey:    pagerDutyDedupKey(serviceName, endpoint.URL, "certificate"),
+		Payload:     payload,
+		Links:       links,
+	}
+}
+
+// pagerDutyDedupKey returns the stable key that groups all events of a problem of an endpoint into one incident
+func pagerDutyDedupKey(serviceName, endpointURL, problem string) string {
+	hash := sha256.Sum256([]byte(serviceName + "\x00" + endpointURL))
+	return fmt.Sprintf("ponghub-%s-%s", problem, hex.EncodeToString(hash[:16]))
+}
+
+// pagerDutySource returns the host of the endpoint, or the endpoint itself if it is not a URL
+func pagerDutySource(endpointURL string) string {
+	if u, err := url.Parse(endpointURL); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return endpointURL
+}
+
+// pagerDutyDetails collects the check results of the endpoint of an event
+func pagerDutyDetails(event notifierTypes.Event) map[string]any {
+	endpoint := event.Endpoint
+	details := map[string]any{
+		"url":      endpoint.URL,
+		"attempts": fmt.Sprintf("%d/%d successful", endpoint.SuccessNum, endpoint.AttemptNum),
+	}
+	if endpoint.StatusCode > 0 {
+		details["status_code"] = endpoint.StatusCode
+	}
+	if len(endpoint.FailureDetails) > 0 {
+		details["last_error"] = endpoint.FailureDetails[len(endpoint.FailureDetails)-1]
+	}
+	if event.Status == alert_status.DEGRADED {
+		details["response_time"] = endpoint.ResponseTime.String()
+		details["warn_latency"] = endpoint.WarnLatency.String()
+	}
+	if event.IsImpacted() {
+		details["impacted_by"] = event.ImpactedBy
+	}
+	return details
+}
@@ -0,0 +1,201 @@
+package channels
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/alert_status"
+	"github.com/wcy-dt/ponghub/internal/types/types/severity"
+)
+
+// newPagerDutyServer starts a test Events API server that records the events sent to it
+func newPagerDutyServer(t *testing.T, events *[]pagerDutyEvent) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event pagerDutyEvent
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("Failed to decode PagerDuty event: %v", err)
+		}
+		*events = append(*events, event)
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status":"success","dedup_key":"` + event.DedupKey + `"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestPagerDutyNotifier_TriggerAndResolve(t *testing.T) {
+	var events []pagerDutyEvent
+	server := newPagerDutyServer(t, &events)
+	notifier := NewPagerDutyNotifier(&configure.PagerDutyConfig{RoutingKey: "R0UT1NGK3Y", EventsURL: server.URL})
+
+	endpoint := checker.Endpoint{
+		URL:            "https://gateway.example.com/health",
+		StatusCode:     502,
+		AttemptNum:     3,
+		FailureDetails: []string{"status code 502 not in [200]"},
+	}
+	down := notifierTypes.Notification{
+		Events: []notifierTypes.Event{
+			{ServiceName: "Gateway", Endpoint: endpoint, Status: "down", PreviousStatus: "up"},
+			{ServiceName: "Billing", Endpoint: checker.Endpoint{URL: "https://billing.example.com"}, Status: "down", PreviousStatus: "up", ImpactedBy: "Gateway"},
+			{ServiceName: "Cache", Endpoint: checker.Endpoint{URL: "cache.example.com:6379"}, Status: "degraded", PreviousStatus: "up"},
+		},
+		GeneratedAt:   time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		StatusPageURL: "https://status.example.com",
+	}
+	if err := notifier.SendNotification(down); err != nil {
+		t.Fatalf("SendNotification failed: %v", err)
+	}
+
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 trigger events, got %d", len(events))
+	}
+	trigger := events[0]
+	if trigger.RoutingKey != "R0UT1NGK3Y" || trigger.EventAction != "trigger" || trigger.Payload == nil {
+		t.Fatalf("Expected a trigger event with the routing key, got %+v", trigger)
+	}
+	if trigger.Payload.Severity != "critical" || trigger.Payload.Source != "gateway.example.com" || trigger.Payload.Component != "Gateway" {
+		t.Errorf("Expected a critical event from the gateway, got %+v", trigger.Payload)
+	}
+	details := trigger.Payload.CustomDetails
+	if details["status_code"] != float64(502) || details["last_error"] != "status code 502 not in [200]" || details["attempts"] != "0/3 successful" {
+		t.Errorf("Expected the check results as custom details, got %+v", details)
+	}
+	if len(trigger.Links) != 1 || trigger.Links[0].Href != down.StatusPageURL {
+		t.Errorf("Expected a link to the status page, got %+v", trigger.Links)
+	}
+	if events[1].Payload.Severity != "error" || events[1].Payload.CustomDetails["impacted_by"] != "Gateway" {
+		t.Errorf("Expected the impacted service to name its root cause, got %+v", events[1].Payload)
+	}
+	if events[2].Payload.Severity != "warning" || events[2].Payload.Source != "cache.example.com:6379" {
+		t.Errorf("Expected a warning for the degraded service, got %+v", events[2].Payload)
+	}
+
+	// reminders do not create new events, recoveries resolve the incident with the same dedup key
+	recovered := notifierTypes.Notification{
+		Events: []notifierTypes.Event{
+			{ServiceName: "Billing", Endpoint: checker.Endpoint{URL: "https://billing.example.com"}, Status: "down", PreviousStatus: "down", IsReminder: true},
+			{ServiceName: "Gateway", Endpoint: endpoint, Status: "up", PreviousStatus: "down", Duration: time.Minute},
+		},
+	}
+	if err := notifier.SendNotification(recovered); err != nil {
+		t.Fatalf("SendNotification failed: %v", err)
+	}
+
+	if len(events) != 4 {
+		t.Fatalf("Expected a single resolve event, got %d events", len(events)-3)
+	}
+	resolve := events[3]
+	if resolve.EventAction != "resolve" || resolve.DedupKey != trigger.DedupKey || resolve.Payload != nil {
+		t.Errorf("Expected a resolve event with the dedup key %s, got %+v", trigger.DedupKey, resolve)
+	}
+}
+
+func TestPagerDutyNotifier_CertProblems(t *testing.T) {
+	events := buildPagerDutyEvents(notifierTypes.Notification{
+		CertProblems: map[string][]checker.Endpoint{
+			"Website": {{URL: "https://example.com", CertRemainingDays: 5}},
+			"API":     {{URL: "https://api.example.com", IsCertExpired: true}},
+		},
+	})
+
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	if events[0].Payload.Component != "API" || events[0].Payload.Severity != "error" {
+		t.Errorf("Expected an error for the expired certificate first, got %+v", events[0].Payload)
+	}
+	if events[1].Payload.Severity != "warning" || !strings.Contains(events[1].Payload.Summary, "expires in 5 days") {
+		t.Errorf("Expected a warning for the expiring certificate, got %+v", events[1].Payload)
+	}
+	if events[1].DedupKey == pagerDutyDedupKey("Website", "https://example.com", "availability") {
+		t.Error("Expected certificate incidents to be separate from availability incidents")
+	}
+}
+
+func TestPagerDutyNotifier_CertRecoveries(t *testing.T) {
+	var events []pagerDutyEvent
+	server := newPagerDutyServer(t, &events)
+	notifier := NewPagerDutyNotifier(&configure.PagerDutyConfig{RoutingKey: "R0UT1NGK3Y", EventsURL: server.URL})
+
+	notification := notifierTypes.Notification{
+		CertRecoveries: map[string][]checker.Endpoint{"Website": {{URL: "https://example.com", IsHTTPS: true, CertRemainingDays: 90}}},
+	}
+	if err := notifier.ResolveIncidents(notification); err != nil {
+		t.Fatalf("ResolveIncidents failed: %v", err)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("Expected a single resolve event, got %d", len(events))
+	}
+	if events[0].EventAction != "resolve" || events[0].DedupKey != pagerDutyDedupKey("Website", "https://example.com", "certificate") {
+		t.Errorf("Expected the certificate incident to be resolved, got %+v", events[0])
+	}
+}
+
+func TestPagerDutyNotifier_RemovedEndpoints(t *testing.T) {
+	events := buildPagerDutyResolveEvents(notifierTypes.Notification{
+		Removed: notifierTypes.State{
+			"Gateway": {
+				"https://gateway.example.com": {Status: "down"},
+				"https://old.example.com":     {Status: "up", CertLastNotified: "2025-01-01T10:00:00Z"},
+			},
+		},
+	})
+
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 resolve events, got %d", len(events))
+	}
+	if events[0].EventAction != "resolve" || events[0].DedupKey != pagerDutyDedupKey("Gateway", "https://gateway.example.com", "availability") {
+		t.Errorf("Expected the outage of the removed endpoint to be resolved, got %+v", events[0])
+	}
+	if events[1].EventAction != "resolve" || events[1].DedupKey != pagerDutyDedupKey("Gateway", "https://old.example.com", "certificate") {
+		t.Errorf("Expected the certificate incident of the removed endpoint to be resolved, got %+v", events[1])
+	}
+}
+
+func TestPagerDutyNotifier_ConfiguredSeverity(t *testing.T) {
+	events := buildPagerDutyEvents(notifierTypes.Notification{
+		Events: []notifierTypes.Event{
+			{ServiceName: "Payments", Endpoint: checker.Endpoint{URL: "https://pay.example.com"}, Status: alert_status.DEGRADED},
+		},
+		CertProblems: map[string][]checker.Endpoint{"Blog": {{URL: "https://blog.example.com", IsCertExpired: true}}},
+		Severities:   map[string]severity.Severity{"Payments": severity.CRITICAL, "Blog": severity.INFO},
+	})
+
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	if events[0].Payload.Severity != "critical" || events[1].Payload.Severity != "info" {
+		t.Errorf("Expected the configured severities critical and info, got %s and %s", events[0].Payload.Severity, events[1].Payload.Severity)
+	}
+}
+
+func TestPagerDutyDedupKey(t *testing.T) {
+	key := pagerDutyDedupKey("Gateway", "https://gateway.example.com", "availability")
+	if key != pagerDutyDedupKey("Gateway", "https://gateway.example.com", "availability") {
+		t.Error("Expected the dedup key to be stable")
+	}
+	if key == pagerDutyDedupKey("Gateway", "https://gateway.example.com/health", "availability") ||
+		key == pagerDutyDedupKey("Billing", "https://gateway.example.com", "availability") {
+		t.Error("Expected different endpoints and services to have different dedup keys")
+	}
+	if len(key) > 255 {
+		t.Errorf("Expected the dedup key to fit the Events API limit, got %d characters", len(key))
+	}
+}
+
+func TestPagerDutyNotifier_MissingRoutingKey(t *testing.T) {
+	t.Setenv("PAGERDUTY_ROUTING_KEY", "")
+	notifier := NewPagerDutyNotifier(&configure.PagerDutyConfig{})
+	if err := notifier.SendNotification(notifierTypes.Notification{}); err == nil {
+		t.Error("Expected an error without a routing key")
+	}
+}
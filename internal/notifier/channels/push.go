@@ -0,0 +1,70 @@
+package channels
+
+import (
+	"slices"
+
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/alert_status"
+)
+
+// pushPriority is the priority of a push notification, using the levels of ntfy
+type pushPriority int
+
+const (
+	priorityMin pushPriority = iota + 1
+	priorityLow
+	priorityDefault
+	priorityHigh
+	priorityUrgent
+)
+
+// notificationPriority returns the priority of the most severe problem of a notification: unavailable services are
+// urgent, degraded or impacted services and expired certificates are high, recoveries are default and certificates
+// that expire soon are low
+func notificationPriority(notification notifierTypes.Notification) pushPriority {
+	priority := priorityMin
+	for _, event := range notification.Events {
+		switch {
+		case event.Status == alert_status.DOWN && !event.IsImpacted():
+			priority = max(priority, priorityUrgent)
+		case event.Status != alert_status.UP:
+			priority = max(priority, priorityHigh)
+		default:
+			priority = max(priority, priorityDefault)
+		}
+	}
+	for _, endpoints := range notification.CertProblems {
+		for _, endpoint := range endpoints {
+			if endpoint.IsCertExpired {
+				priority = max(priority, priorityHigh)
+			} else {
+				priority = max(priority, priorityLow)
+			}
+		}
+	}
+	return priority
+}
+
+// notificationTags returns the emoji shortcodes of the kinds of problems in a notification, ntfy shows them as emoji
+func notificationTags(notification notifierTypes.Notification) []string {
+	var tags []string
+	for _, section := range buildSections(notification) {
+		var tag string
+		switch section.Kind {
+		case sectionDown:
+			tag = "rotating_light"
+		case sectionDegraded:
+			tag = "turtle"
+		case sectionImpacted:
+			tag = "link"
+		case sectionRecovered:
+			tag = "white_check_mark"
+		default:
+			tag = "lock"
+		}
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,50 @@
+package channels
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+func TestNotificationPriority(t *testing.T) {
+	expiring := map[string][]checker.Endpoint{"Website": {{URL: "https://example.com", CertRemainingDays: 5}}}
+	expired := map[string][]checker.Endpoint{"Website": {{URL: "https://example.com", IsCertExpired: true}}}
+
+	tests := []struct {
+		name         string
+		notification notifierTypes.Notification
+		expected     pushPriority
+	}{
+		{"down", notifierTypes.Notification{Events: []notifierTypes.Event{{Status: "up"}, {Status: "down"}}, CertProblems: expiring}, priorityUrgent},
+		{"impacted", notifierTypes.Notification{Events: []notifierTypes.Event{{Status: "down", ImpactedBy: "Gateway"}}}, priorityHigh},
+		{"degraded", notifierTypes.Notification{Events: []notifierTypes.Event{{Status: "degraded"}}}, priorityHigh},
+		{"recovered", notifierTypes.Notification{Events: []notifierTypes.Event{{Status: "up"}}}, priorityDefault},
+		{"cert expired", notifierTypes.Notification{CertProblems: expired}, priorityHigh},
+		{"cert expiring", notifierTypes.Notification{CertProblems: expiring}, priorityLow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if priority := notificationPriority(tt.notification); priority != tt.expected {
+				t.Errorf("Expected priority %d, got %d", tt.expected, priority)
+			}
+		})
+	}
+}
+
+func TestNotificationTags(t *testing.T) {
+	tags := notificationTags(notifierTypes.Notification{
+		Events: []notifierTypes.Event{
+			{ServiceName: "Gateway", Status: "down"},
+			{ServiceName: "Billing", Status: "down"},
+			{ServiceName: "Search", Status: "up", PreviousStatus: "down"},
+		},
+		CertProblems: map[string][]checker.Endpoint{"Website": {{URL: "https://example.com"}}},
+	})
+
+	if expected := []string{"rotating_light", "white_check_mark", "lock"}; !slices.Equal(tags, expected) {
+		t.Errorf("Expected tags %v, got %v", expected, tags)
+	}
+}
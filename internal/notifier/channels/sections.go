@@ -0,0 +1,238 @@
+package channels
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/alert_status"
+)
+
+// sectionKind is the type of incident a section of a rich notification reports
+type sectionKind string
+
+const (
+	sectionDown      sectionKind = "down"
+	sectionDegraded  sectionKind = "degraded"
+	sectionImpacted  sectionKind = "impacted"
+	sectionRecovered sectionKind = "recovered"
+	sectionCert      sectionKind = "cert"
+	sectionCertError sectionKind = "cert_expired"
+)
+
+// Colours of the section kinds, matching the colours of the status page
+var sectionColors = map[sectionKind]string{
+	sectionDown:      "#ff4136",
+	sectionDegraded:  "#a0d911",
+	sectionImpacted:  "#ff851b",
+	sectionRecovered: "#2ecc40",
+	sectionCert:      "#ffb700",
+	sectionCertError: "#ff4136",
+}
+
+type (
+	// sectionItem is an endpoint of a section with the plain text lines describing it
+	sectionItem struct {
+		URL      string
+		Endpoint checker.Endpoint
+		Details  []string
+		// Notes are the details that are not check results, such as the duration of an outage
+		Notes []string
+	}
+
+	// notificationSection groups the endpoints of a single service with the same kind of incident
+	notificationSection struct {
+		Kind        sectionKind
+		ServiceName string
+		// Summary completes the service name, such as "is down"
+		Summary string
+		Items   []sectionItem
+		// ImpactedServices are the services folded into this outage
+		ImpactedServices []string
+	}
+)
+
+// Color returns the colour of the section as a hex string
+func (s notificationSection) Color() string {
+	return sectionColors[s.Kind]
+}
+
+// Emoji returns the emoji marking the kind of the section
+func (s notificationSection) Emoji() string {
+	switch s.Kind {
+	case sectionDown:
+		return "🔴"
+	case sectionDegraded:
+		return "🐢"
+	case sectionImpacted:
+		return "🔗"
+	case sectionRecovered:
+		return "✅"
+	default:
+		return "🔐"
+	}
+}
+
+// buildSections splits a notification into sections: outages, degraded services, recoveries,
+// services impacted by an outage notified earlier, and certificate problems sorted by service
+func buildSections(notification notifierTypes.Notification) []notificationSection {
+	impactedServices, unfoldedEvents := notifierTypes.FoldImpactedEvents(notification.Events)
+
+	var sections []notificationSection
+	for _, group := range groupEventsByService(notification.Events) {
+		event := group[0]
+		section := notificationSection{ServiceName: event.ServiceName}
+		switch {
+		case event.IsImpacted():
+			continue
+		case event.Status == alert_status.DOWN:
+			section.Kind, section.Summary = sectionDown, "is down"
+			section.ImpactedServices = impactedServices[event.ServiceName]
+		case event.Status == alert_status.DEGRADED:
+			section.Kind, section.Summary = sectionDegraded, "is degraded"
+		default:
+			section.Kind, section.Summary = sectionRecovered, "has recovered"
+		}
+		section.Items = describeEvents(group)
+		sections = append(sections, section)
+	}
+	for _, group := range groupEventsByService(unfoldedEvents) {
+		sections = append(sections, notificationSection{
+			Kind:        sectionImpacted,
+			ServiceName: group[0].ServiceName,
+			Summary:     "is impacted by " + group[0].ImpactedBy,
+			Items:       describeEvents(group),
+		})
+	}
+
+	// map order is random, so the certificate problems are sorted by service
+	certServiceNames := make([]string, 0, len(notification.CertProblems))
+	for serviceName := range notification.CertProblems {
+		certServiceNames = append(certServiceNames, serviceName)
+	}
+	slices.Sort(certServiceNames)
+	for _, serviceName := range certServiceNames {
+		sections = append(sections, newCertSection(serviceName, notification.CertProblems[serviceName]))
+	}
+	return sections
+}
+
+// groupEventsByService splits the events into runs of consecutive events of the same service, status and root cause
+func groupEventsByService(events []notifierTypes.Event) [][]notifierTypes.Event {
+	var groups [][]notifierTypes.Event
+	for i, event := range events {
+		if i > 0 {
+			previous := events[i-1]
+			if event.ServiceName == previous.ServiceName && event.Status == previous.Status && event.ImpactedBy == previous.ImpactedBy {
+				groups[len(groups)-1] = append(groups[len(groups)-1], event)
+				continue
+			}
+		}
+		groups = append(groups, []notifierTypes.Event{event})
+	}
+	return groups
+}
+
+// describeEvents describes the endpoints of the events
+func describeEvents(events []notifierTypes.Event) []sectionItem {
+	items := make([]sectionItem, 0, len(events))
+	for _, event := range events {
+		endpoint := event.Endpoint
+		item := sectionItem{URL: endpoint.URL, Endpoint: endpoint}
+
+		switch {
+		case event.IsRecovery():
+			item.Notes = append(item.Notes, fmt.Sprintf("Was %s for %s", event.PreviousStatus, event.Duration.Round(time.Second)))
+		case event.Status == alert_status.DEGRADED:
+			item.Notes = append(item.Notes, fmt.Sprintf("Response time %v (warn_latency %v)", endpoint.ResponseTime, endpoint.WarnLatency))
+		default:
+			attempts := fmt.Sprintf("%d/%d attempts successful", endpoint.SuccessNum, endpoint.AttemptNum)
+			if endpoint.StatusCode > 0 {
+				attempts = fmt.Sprintf("Status code %d · %s", endpoint.StatusCode, attempts)
+			}
+			item.Details = append(item.Details, attempts)
+			if len(endpoint.FailureDetails) > 0 {
+				item.Details = append(item.Details, "Last error: "+endpoint.FailureDetails[len(endpoint.FailureDetails)-1])
+			}
+		}
+		if event.IsReminder {
+			item.Notes = append(item.Notes, fmt.Sprintf("Still %s for %s", event.Status, event.Duration.Round(time.Second)))
+		}
+		item.Details = append(item.Details, item.Notes...)
+		items = append(items, item)
+	}
+	return items
+}
+
+// newCertSection creates the section of the certificate problems of a single service
+func newCertSection(serviceName string, endpoints []checker.Endpoint) notificationSection {
+	section := notificationSection{Kind: sectionCert, ServiceName: serviceName, Summary: "has certificate issues"}
+	for _, endpoint := range endpoints {
+		item := sectionItem{URL: endpoint.URL, Endpoint: endpoint}
+		if endpoint.IsCertExpired {
+			section.Kind = sectionCertError
+			item.Details = []string{"❌ Certificate expired"}
+		} else {
+			item.Details = []string{fmt.Sprintf("⚠️ Certificate expires in %d days", endpoint.CertRemainingDays)}
+		}
+		section.Items = append(section.Items, item)
+	}
+	return section
+}
+
+// summarizeNotification counts the endpoints of each kind of incident for the summary line of a notification
+func summarizeNotification(notification notifierTypes.Notification) string {
+	var downNum, degradedNum, impactedNum, recoveredNum, certIssueNum int
+	for _, event := range notification.Events {
+		switch {
+		case event.IsImpacted():
+			impactedNum++
+		case event.Status == alert_status.DOWN:
+			downNum++
+		case event.Status == alert_status.DEGRADED:
+			degradedNum++
+		default:
+			recoveredNum++
+		}
+	}
+	for _, endpoints := range notification.CertProblems {
+		certIssueNum += len(endpoints)
+	}
+	return fmt.Sprintf("Unavailable: %d · Degraded: %d · Impacted: %d · Recovered: %d · Certificate issues: %d",
+		downNum, degradedNum, impactedNum, recoveredNum, certIssueNum)
+}
+
+// formatMarkdownSections formats the sections of a notification as markdown for chat robots, ending each line with
+// lineBreak since some robots need blank lines to break lines
+func formatMarkdownSections(notification notifierTypes.Notification, lineBreak string) string {
+	var text strings.Builder
+	for _, section := range buildSections(notification) {
+		text.WriteString(fmt.Sprintf("**%s %s %s**%s", section.Emoji(), escapeMarkdown(section.ServiceName), escapeMarkdown(section.Summary), lineBreak))
+		for _, item := range section.Items {
+			text.WriteString("- " + formatMarkdownURL(item.URL) + lineBreak)
+			for _, detail := range item.Details {
+				text.WriteString("  " + escapeMarkdown(detail) + lineBreak)
+			}
+		}
+		if len(section.ImpactedServices) > 0 {
+			text.WriteString("⛓️ Impacted services: " + escapeMarkdown(strings.Join(section.ImpactedServices, ", ")) + lineBreak)
+		}
+		text.WriteString("\n")
+	}
+	text.WriteString(summarizeNotification(notification) + lineBreak)
+	if notification.StatusPageURL != "" {
+		text.WriteString(fmt.Sprintf("[View status page](%s)%s", notification.StatusPageURL, lineBreak))
+	}
+	return text.String()
+}
+
+// formatMarkdownURL links HTTP endpoints, other endpoints such as host:port are shown as code
+func formatMarkdownURL(url string) string {
+	if isHTTPURL(url) {
+		return fmt.Sprintf("[%s](%s)", escapeMarkdown(url), url)
+	}
+	return "`" + strings.ReplaceAll(url, "`", "'") + "`"
+}
@@ -0,0 +1,172 @@
+package channels
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/wcy-dt/ponghub/internal/common/params"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+// slackMaxTextLength is the maximum length of the text of a Slack section block
+const slackMaxTextLength = 3000
+
+type (
+	// slackBlock is a Block Kit block, its fields depend on the block type
+	slackBlock map[string]any
+
+	// slackAttachment is a colour-coded attachment holding Block Kit blocks
+	slackAttachment struct {
+		Color  string       `json:"color"`
+		Blocks []slackBlock `json:"blocks"`
+	}
+
+	// slackMessage is the payload posted to a Slack incoming webhook
+	slackMessage struct {
+		Text        string            `json:"text"`
+		Channel     string            `json:"channel,omitempty"`
+		Username    string            `json:"username,omitempty"`
+		IconEmoji   string            `json:"icon_emoji,omitempty"`
+		Blocks      []slackBlock      `json:"blocks"`
+		Attachments []slackAttachment `json:"attachments,omitempty"`
+	}
+)
+
+// SlackNotifier implements Slack notifications through incoming webhooks with Block Kit messages
+type SlackNotifier struct {
+	config *configure.SlackConfig
+}
+
+// NewSlackNotifier creates a new Slack notifier
+func NewSlackNotifier(config *configure.SlackConfig) *SlackNotifier {
+	return &SlackNotifier{config: config}
+}
+
+// Send sends a plain notification with the message as preformatted text
+func (s *SlackNotifier) Send(title, message string) error {
+	blocks := []slackBlock{newSlackHeaderBlock(title)}
+	for _, chunk := range splitText(escapeSlackText(message), slackMaxTextLength-6) {
+		blocks = append(blocks, newSlackSectionBlock("```"+chunk+"```"))
+	}
+	return s.post(s.newMessage(title, blocks, nil))
+}
+
+// SendNotification sends a Block Kit message with one colour-coded attachment per service and incident type
+func (s *SlackNotifier) SendNotification(notification notifierTypes.Notification) error {
+	return s.post(s.buildMessage(notification))
+}
+
+// buildMessage builds the Block Kit message of a notification
+func (s *SlackNotifier) buildMessage(notification notifierTypes.Notification) slackMessage {
+	blocks := []slackBlock{
+		newSlackHeaderBlock(notification.Title),
+		newSlackContextBlock(fmt.Sprintf("Generated at %s", notification.GeneratedAt.Format("2006-01-02 15:04:05"))),
+	}
+	if notification.StatusPageURL != "" {
+		blocks = append(blocks, slackBlock{
+			"type": "actions",
+			"elements": []slackBlock{{
+				"type": "button",
+				"text": slackBlock{"type": "plain_text", "text": "View status page"},
+				"url":  notification.StatusPageURL,
+			}},
+		})
+	}
+
+	var attachments []slackAttachment
+	for _, section := range buildSections(notification) {
+		attachments = append(attachments, newSlackAttachment(section, notification.StatusPageURL))
+	}
+	blocks = append(blocks, newSlackContextBlock(summarizeNotification(notification)))
+
+	return s.newMessage(notification.Title, blocks, attachments)
+}
+
+// newMessage creates a message with the configured channel, username and icon
+func (s *SlackNotifier) newMessage(title string, blocks []slackBlock, attachments []slackAttachment) slackMessage {
+	return slackMessage{
+		Text:        title,
+		Channel:     s.config.Channel,
+		Username:    s.config.Username,
+		IconEmoji:   s.config.IconEmoji,
+		Blocks:      blocks,
+		Attachments: attachments,
+	}
+}
+
+// post posts the message to the configured webhook, falling back to the SLACK_WEBHOOK_URL environment variable
+func (s *SlackNotifier) post(message slackMessage) error {
+	url := s.config.WebhookURL
+	if url == "" {
+		url = os.Getenv("SLACK_WEBHOOK_URL")
+	}
+	if url == "" {
+		return fmt.Errorf("slack webhook URL not configured")
+	}
+	url = params.NewParameterResolver().ResolveParameters(url)
+
+	return sendHTTPRequest(url, "POST", message, nil, s.config.Retries, s.config.Timeout, false)
+}
+
+// newSlackAttachment creates the colour-coded attachment of a section
+func newSlackAttachment(section notificationSection, statusPageURL string) slackAttachment {
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("%s *%s* %s\n", section.Emoji(), formatSlackServiceName(section.ServiceName, statusPageURL), escapeSlackText(section.Summary)))
+	for _, item := range section.Items {
+		text.WriteString(fmt.Sprintf("• %s\n", formatSlackURL(item.URL)))
+		for _, detail := range item.Details {
+			text.WriteString(fmt.Sprintf("    %s\n", escapeSlackText(detail)))
+		}
+	}
+	if len(section.ImpactedServices) > 0 {
+		text.WriteString(fmt.Sprintf("⛓️ Impacted services: %s\n", escapeSlackText(strings.Join(section.ImpactedServices, ", "))))
+	}
+	return slackAttachment{Color: section.Color(), Blocks: []slackBlock{newSlackSectionBlock(text.String())}}
+}
+
+// newSlackHeaderBlock creates a header block, Slack limits headers to 150 characters
+func newSlackHeaderBlock(text string) slackBlock {
+	return slackBlock{
+		"type": "header",
+		"text": slackBlock{"type": "plain_text", "text": truncateText(text, 150), "emoji": true},
+	}
+}
+
+// newSlackSectionBlock creates a section block with mrkdwn text
+func newSlackSectionBlock(text string) slackBlock {
+	return slackBlock{
+		"type": "section",
+		"text": slackBlock{"type": "mrkdwn", "text": truncateText(text, slackMaxTextLength)},
+	}
+}
+
+// newSlackContextBlock creates a context block with a single mrkdwn element
+func newSlackContextBlock(text string) slackBlock {
+	return slackBlock{
+		"type":     "context",
+		"elements": []slackBlock{{"type": "mrkdwn", "text": text}},
+	}
+}
+
+// formatSlackServiceName links the service name to the status page if it is configured
+func formatSlackServiceName(serviceName, statusPageURL string) string {
+	if statusPageURL == "" {
+		return escapeSlackText(serviceName)
+	}
+	return fmt.Sprintf("<%s|%s>", escapeSlackText(statusPageURL), escapeSlackText(serviceName))
+}
+
+// formatSlackURL links HTTP endpoints, other endpoints such as host:port are shown as code
+func formatSlackURL(url string) string {
+	if isHTTPURL(url) {
+		return fmt.Sprintf("<%s|%s>", escapeSlackText(url), escapeSlackText(url))
+	}
+	return "`" + escapeSlackText(url) + "`"
+}
+
+// escapeSlackText escapes the characters Slack uses for links and mentions
+func escapeSlackText(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
@@ -0,0 +1,146 @@
+package channels
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+// newSlackServer starts a test server that records the Slack messages posted to it
+func newSlackServer(t *testing.T, messages *[]slackMessage) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected a JSON payload, got %s", r.Header.Get("Content-Type"))
+		}
+		var message slackMessage
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Errorf("Failed to decode Slack message: %v", err)
+		}
+		*messages = append(*messages, message)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// attachmentText returns the text of the section block of an attachment
+func attachmentText(attachment slackAttachment) string {
+	text, _ := attachment.Blocks[0]["text"].(map[string]any)
+	s, _ := text["text"].(string)
+	return s
+}
+
+func TestSlackNotifier_SendNotification(t *testing.T) {
+	var messages []slackMessage
+	server := newSlackServer(t, &messages)
+
+	notification := notifierTypes.Notification{
+		Title: "🚨 PongHub Service Status Alert",
+		Events: []notifierTypes.Event{
+			{
+				ServiceName: "Gateway",
+				Endpoint: checker.Endpoint{
+					URL:            "https://gateway.example.com/health?a=1&b=2",
+					StatusCode:     502,
+					AttemptNum:     3,
+					FailureDetails: []string{"status code 502 not in <200>\nupstream failed"},
+				},
+				Status: "down", PreviousStatus: "up",
+			},
+			{ServiceName: "Billing", Endpoint: checker.Endpoint{URL: "https://billing.example.com"}, Status: "down", PreviousStatus: "up", ImpactedBy: "Gateway"},
+			{ServiceName: "Search", Endpoint: checker.Endpoint{URL: "https://search.example.com"}, Status: "up", PreviousStatus: "down", Duration: 90 * time.Second},
+			{ServiceName: "Cache", Endpoint: checker.Endpoint{URL: "cache.example.com:6379"}, Status: "degraded", PreviousStatus: "up"},
+		},
+		CertProblems: map[string][]checker.Endpoint{
+			"Website": {{URL: "https://example.com", IsHTTPS: true, CertRemainingDays: 5}},
+		},
+		GeneratedAt:   time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		StatusPageURL: "https://status.example.com",
+	}
+
+	notifier := NewSlackNotifier(&configure.SlackConfig{WebhookURL: server.URL, Channel: "#alerts"})
+	if err := notifier.SendNotification(notification); err != nil {
+		t.Fatalf("SendNotification failed: %v", err)
+	}
+
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	message := messages[0]
+	if message.Text != notification.Title || message.Channel != "#alerts" {
+		t.Errorf("Expected the title as fallback text and the configured channel, got %q in %q", message.Text, message.Channel)
+	}
+	if message.Blocks[0]["type"] != "header" || message.Blocks[2]["type"] != "actions" {
+		t.Errorf("Expected a header and a status page button, got %+v", message.Blocks)
+	}
+
+	expectedColors := []string{sectionColors[sectionDown], sectionColors[sectionRecovered], sectionColors[sectionDegraded], sectionColors[sectionCert]}
+	if len(message.Attachments) != len(expectedColors) {
+		t.Fatalf("Expected %d attachments, got %+v", len(expectedColors), message.Attachments)
+	}
+	for i, color := range expectedColors {
+		if message.Attachments[i].Color != color {
+			t.Errorf("Expected attachment %d to be %s, got %s", i, color, message.Attachments[i].Color)
+		}
+	}
+
+	down := attachmentText(message.Attachments[0])
+	for _, expected := range []string{
+		"*<https://status.example.com|Gateway>* is down",
+		"<https://gateway.example.com/health?a=1&amp;b=2|https://gateway.example.com/health?a=1&amp;b=2>",
+		"Last error: status code 502 not in &lt;200&gt;\nupstream failed",
+		"Impacted services: Billing",
+	} {
+		if !strings.Contains(down, expected) {
+			t.Errorf("Expected the down attachment to contain %q, got:\n%s", expected, down)
+		}
+	}
+	if text := attachmentText(message.Attachments[1]); !strings.Contains(text, "Was down for 1m30s") {
+		t.Errorf("Expected the recovery to show the outage duration, got:\n%s", text)
+	}
+	if text := attachmentText(message.Attachments[2]); !strings.Contains(text, "`cache.example.com:6379`") {
+		t.Errorf("Expected non-HTTP endpoints to be shown as code, got:\n%s", text)
+	}
+	if text := attachmentText(message.Attachments[3]); !strings.Contains(text, "Certificate expires in 5 days") {
+		t.Errorf("Expected the certificate attachment to show the remaining days, got:\n%s", text)
+	}
+}
+
+func TestSlackNotifier_Send(t *testing.T) {
+	var messages []slackMessage
+	server := newSlackServer(t, &messages)
+
+	message := strings.Repeat("line with \"quotes\" & <tags>\n", 200)
+	notifier := NewSlackNotifier(&configure.SlackConfig{WebhookURL: server.URL})
+	if err := notifier.Send("Title", message); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	// the multi-line message is split into preformatted sections that keep every line intact
+	var text strings.Builder
+	for _, block := range messages[0].Blocks[1:] {
+		section := block["text"].(map[string]any)["text"].(string)
+		if len([]rune(section)) > slackMaxTextLength {
+			t.Errorf("Expected sections of at most %d characters, got %d", slackMaxTextLength, len([]rune(section)))
+		}
+		text.WriteString(strings.TrimSuffix(strings.TrimPrefix(section, "```"), "```"))
+	}
+	if expected := escapeSlackText(message); text.String() != expected {
+		t.Errorf("Expected the message to be sent unchanged, got:\n%s", text.String())
+	}
+}
+
+func TestSlackNotifier_MissingWebhookURL(t *testing.T) {
+	t.Setenv("SLACK_WEBHOOK_URL", "")
+	notifier := NewSlackNotifier(&configure.SlackConfig{})
+	if err := notifier.Send("Title", "Message"); err == nil {
+		t.Error("Expected an error without a webhook URL")
+	}
+}
@@ -0,0 +1,187 @@
+package channels
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/wcy-dt/ponghub/internal/common/params"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+// teamsMaxCardSize keeps Adaptive Cards below the 28 KB limit of Teams webhooks, leaving room for the envelope
+const teamsMaxCardSize = 25000
+
+// Container styles of the section kinds, Adaptive Cards only support a fixed set of colours
+var teamsSectionStyles = map[sectionKind]string{
+	sectionDown:      "attention",
+	sectionDegraded:  "warning",
+	sectionImpacted:  "emphasis",
+	sectionRecovered: "good",
+	sectionCert:      "warning",
+	sectionCertError: "attention",
+}
+
+type (
+	// teamsElement is an Adaptive Card element or action, its fields depend on the element type
+	teamsElement map[string]any
+
+	// teamsCard is an Adaptive Card
+	teamsCard struct {
+		Schema  string            `json:"$schema"`
+		Type    string            `json:"type"`
+		Version string            `json:"version"`
+		Body    []teamsElement    `json:"body"`
+		Actions []teamsElement    `json:"actions,omitempty"`
+		MSTeams map[string]string `json:"msteams,omitempty"`
+	}
+
+	// teamsAttachment wraps an Adaptive Card
+	teamsAttachment struct {
+		ContentType string    `json:"contentType"`
+		Content     teamsCard `json:"content"`
+	}
+
+	// teamsMessage is the payload posted to a Teams incoming webhook
+	teamsMessage struct {
+		Type        string            `json:"type"`
+		Attachments []teamsAttachment `json:"attachments"`
+	}
+)
+
+// TeamsNotifier implements Microsoft Teams notifications through incoming webhooks with Adaptive Cards
+type TeamsNotifier struct {
+	config *configure.TeamsConfig
+}
+
+// NewTeamsNotifier creates a new Teams notifier
+func NewTeamsNotifier(config *configure.TeamsConfig) *TeamsNotifier {
+	return &TeamsNotifier{config: config}
+}
+
+// Send sends a plain notification with the message as monospace text
+func (t *TeamsNotifier) Send(title, message string) error {
+	body := []teamsElement{
+		newTeamsTitleBlock(title),
+		newTeamsTextBlock(formatTeamsText(message), teamsElement{"fontType": "Monospace"}),
+	}
+	return t.post(newTeamsMessage(body, nil))
+}
+
+// SendNotification sends an Adaptive Card with one colour-coded container per service and incident type
+func (t *TeamsNotifier) SendNotification(notification notifierTypes.Notification) error {
+	return t.post(buildTeamsMessage(notification))
+}
+
+// post posts the message to the configured webhook, falling back to the TEAMS_WEBHOOK_URL environment variable
+func (t *TeamsNotifier) post(message teamsMessage) error {
+	url := t.config.WebhookURL
+	if url == "" {
+		url = os.Getenv("TEAMS_WEBHOOK_URL")
+	}
+	if url == "" {
+		return fmt.Errorf("teams webhook URL not configured")
+	}
+	url = params.NewParameterResolver().ResolveParameters(url)
+
+	return sendHTTPRequest(url, "POST", message, nil, t.config.Retries, t.config.Timeout, false)
+}
+
+// buildTeamsMessage builds the Adaptive Card of a notification, leaving out the sections that do not fit into the card
+func buildTeamsMessage(notification notifierTypes.Notification) teamsMessage {
+	body := []teamsElement{
+		newTeamsTitleBlock(notification.Title),
+		newTeamsTextBlock(fmt.Sprintf("Generated at %s", notification.GeneratedAt.Format("2006-01-02 15:04:05")),
+			teamsElement{"isSubtle": true, "spacing": "None"}),
+	}
+
+	sections := buildSections(notification)
+	size := 0
+	for i, section := range sections {
+		container := newTeamsContainer(section)
+		encoded, _ := json.Marshal(container)
+		if size+len(encoded) > teamsMaxCardSize {
+			body = append(body, newTeamsTextBlock(fmt.Sprintf("… and %d more, see the status page for details", len(sections)-i),
+				teamsElement{"isSubtle": true}))
+			break
+		}
+		body = append(body, container)
+		size += len(encoded)
+	}
+	body = append(body, newTeamsTextBlock(summarizeNotification(notification), teamsElement{"isSubtle": true, "separator": true}))
+
+	var actions []teamsElement
+	if notification.StatusPageURL != "" {
+		actions = append(actions, teamsElement{"type": "Action.OpenUrl", "title": "View status page", "url": notification.StatusPageURL})
+	}
+	return newTeamsMessage(body, actions)
+}
+
+// newTeamsMessage wraps the card body and actions into a webhook message
+func newTeamsMessage(body, actions []teamsElement) teamsMessage {
+	return teamsMessage{
+		Type: "message",
+		Attachments: []teamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content: teamsCard{
+				Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+				Type:    "AdaptiveCard",
+				Version: "1.4",
+				Body:    body,
+				Actions: actions,
+				MSTeams: map[string]string{"width": "Full"},
+			},
+		}},
+	}
+}
+
+// newTeamsContainer creates the colour-coded container of a section
+func newTeamsContainer(section notificationSection) teamsElement {
+	items := []teamsElement{
+		newTeamsTextBlock(fmt.Sprintf("%s **%s** %s", section.Emoji(), escapeMarkdown(section.ServiceName), escapeMarkdown(section.Summary)),
+			teamsElement{"size": "Medium"}),
+	}
+	for _, item := range section.Items {
+		items = append(items, newTeamsTextBlock(formatTeamsURL(item.URL), teamsElement{"spacing": "Small"}))
+		if len(item.Details) > 0 {
+			details := make([]string, 0, len(item.Details))
+			for _, detail := range item.Details {
+				details = append(details, formatTeamsText(detail))
+			}
+			items = append(items, newTeamsTextBlock(strings.Join(details, "\n\n"), teamsElement{"isSubtle": true, "spacing": "None"}))
+		}
+	}
+	if len(section.ImpactedServices) > 0 {
+		items = append(items, newTeamsTextBlock("⛓️ Impacted services: "+escapeMarkdown(strings.Join(section.ImpactedServices, ", ")), nil))
+	}
+	return teamsElement{"type": "Container", "style": teamsSectionStyles[section.Kind], "bleed": true, "items": items}
+}
+
+// newTeamsTitleBlock creates the large bold title of a card
+func newTeamsTitleBlock(title string) teamsElement {
+	return newTeamsTextBlock(escapeMarkdown(title), teamsElement{"size": "Large", "weight": "Bolder"})
+}
+
+// newTeamsTextBlock creates a wrapping text block with the given extra properties
+func newTeamsTextBlock(text string, properties teamsElement) teamsElement {
+	block := teamsElement{"type": "TextBlock", "text": text, "wrap": true}
+	for key, value := range properties {
+		block[key] = value
+	}
+	return block
+}
+
+// formatTeamsURL links HTTP endpoints, other endpoints such as host:port are shown as plain text
+func formatTeamsURL(url string) string {
+	if isHTTPURL(url) {
+		return fmt.Sprintf("[%s](%s)", escapeMarkdown(url), url)
+	}
+	return escapeMarkdown(url)
+}
+
+// formatTeamsText escapes text and keeps its line breaks, Teams only breaks lines at blank lines
+func formatTeamsText(text string) string {
+	return strings.ReplaceAll(escapeMarkdown(text), "\n", "\n\n")
+}
@@ -0,0 +1,138 @@
+package channels
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+// containerText joins the texts of the text blocks of a container
+func containerText(container map[string]any) string {
+	var texts []string
+	items, _ := container["items"].([]any)
+	for _, item := range items {
+		block, _ := item.(map[string]any)
+		text, _ := block["text"].(string)
+		texts = append(texts, text)
+	}
+	return strings.Join(texts, "\n")
+}
+
+func TestTeamsNotifier_SendNotification(t *testing.T) {
+	var messages []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Errorf("Failed to decode Teams message: %v", err)
+		}
+		messages = append(messages, message)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	notification := notifierTypes.Notification{
+		Title: "🚨 PongHub Service Status Alert",
+		Events: []notifierTypes.Event{
+			{
+				ServiceName: "Gateway",
+				Endpoint:    checker.Endpoint{URL: "https://gateway.example.com/health", StatusCode: 502, AttemptNum: 3},
+				Status:      "down", PreviousStatus: "up",
+			},
+			{ServiceName: "Cache", Endpoint: checker.Endpoint{URL: "cache.example.com:6379"}, Status: "degraded", PreviousStatus: "up"},
+		},
+		CertProblems: map[string][]checker.Endpoint{
+			"Website": {{URL: "https://example.com", IsHTTPS: true, CertRemainingDays: 5}},
+		},
+		GeneratedAt:   time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		StatusPageURL: "https://status.example.com",
+	}
+
+	notifier := NewTeamsNotifier(&configure.TeamsConfig{WebhookURL: server.URL})
+	if err := notifier.SendNotification(notification); err != nil {
+		t.Fatalf("SendNotification failed: %v", err)
+	}
+
+	if len(messages) != 1 || messages[0]["type"] != "message" {
+		t.Fatalf("Expected 1 message, got %+v", messages)
+	}
+	attachment := messages[0]["attachments"].([]any)[0].(map[string]any)
+	if attachment["contentType"] != "application/vnd.microsoft.card.adaptive" {
+		t.Errorf("Expected an Adaptive Card, got %v", attachment["contentType"])
+	}
+	card := attachment["content"].(map[string]any)
+	if card["type"] != "AdaptiveCard" || card["version"] != "1.4" {
+		t.Errorf("Expected an Adaptive Card 1.4, got %v %v", card["type"], card["version"])
+	}
+	actions := card["actions"].([]any)
+	if action := actions[0].(map[string]any); action["type"] != "Action.OpenUrl" || action["url"] != notification.StatusPageURL {
+		t.Errorf("Expected a button to the status page, got %+v", action)
+	}
+
+	var containers []map[string]any
+	for _, element := range card["body"].([]any) {
+		if block := element.(map[string]any); block["type"] == "Container" {
+			containers = append(containers, block)
+		}
+	}
+	expectedStyles := []string{"attention", "warning", "warning"}
+	if len(containers) != len(expectedStyles) {
+		t.Fatalf("Expected %d containers, got %d", len(expectedStyles), len(containers))
+	}
+	for i, style := range expectedStyles {
+		if containers[i]["style"] != style {
+			t.Errorf("Expected container %d to be %s, got %v", i, style, containers[i]["style"])
+		}
+	}
+
+	down := containerText(containers[0])
+	for _, expected := range []string{
+		"🔴 **Gateway** is down",
+		"[https://gateway.example.com/health](https://gateway.example.com/health)",
+		"Status code 502 · 0/3 attempts successful",
+	} {
+		if !strings.Contains(down, expected) {
+			t.Errorf("Expected the down container to contain %q, got:\n%s", expected, down)
+		}
+	}
+	if text := containerText(containers[2]); !strings.Contains(text, "Certificate expires in 5 days") {
+		t.Errorf("Expected the certificate container to show the remaining days, got:\n%s", text)
+	}
+}
+
+func TestBuildTeamsMessage_LimitsCardSize(t *testing.T) {
+	notification := notifierTypes.Notification{Title: "Alert"}
+	for i := range 100 {
+		notification.Events = append(notification.Events, notifierTypes.Event{
+			ServiceName: fmt.Sprintf("Service %d", i),
+			Endpoint:    checker.Endpoint{URL: "https://example.com", FailureDetails: []string{strings.Repeat("x", 500)}},
+			Status:      "down", PreviousStatus: "up",
+		})
+	}
+
+	message := buildTeamsMessage(notification)
+
+	encoded, _ := json.Marshal(message)
+	if len(encoded) > 28*1024 {
+		t.Errorf("Expected the card to stay below 28 KB, got %d bytes", len(encoded))
+	}
+	body := message.Attachments[0].Content.Body
+	if text, _ := body[len(body)-2]["text"].(string); !strings.Contains(text, "more") {
+		t.Errorf("Expected a note about the left out sections, got %q", text)
+	}
+}
+
+func TestTeamsNotifier_MissingWebhookURL(t *testing.T) {
+	t.Setenv("TEAMS_WEBHOOK_URL", "")
+	notifier := NewTeamsNotifier(&configure.TeamsConfig{})
+	if err := notifier.Send("Title", "Message"); err == nil {
+		t.Error("Expected an error without a webhook URL")
+	}
+}
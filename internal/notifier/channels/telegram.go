@@ -0,0 +1,148 @@
+package channels
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/wcy-dt/ponghub/internal/common/params"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+const (
+	// telegramAPIURL is the default Telegram Bot API server
+	telegramAPIURL = "https://api.telegram.org"
+	// telegramMaxTextLength is the maximum length of a message in UTF-16 code units, after entities are parsed
+	telegramMaxTextLength = 4096
+)
+
+type (
+	// telegramLinkPreviewOptions controls the link preview of a message
+	telegramLinkPreviewOptions struct {
+		IsDisabled bool `json:"is_disabled"`
+	}
+
+	// telegramMessage is the payload of the sendMessage method of the Bot API
+	telegramMessage struct {
+		ChatID              string                     `json:"chat_id"`
+		Text                string                     `json:"text"`
+		ParseMode           string                     `json:"parse_mode"`
+		DisableNotification bool                       `json:"disable_notification,omitempty"`
+		LinkPreviewOptions  telegramLinkPreviewOptions `json:"link_preview_options"`
+	}
+)
+
+// TelegramNotifier implements Telegram notifications through a bot
+type TelegramNotifier struct {
+	config *configure.TelegramConfig
+}
+
+// NewTelegramNotifier creates a new Telegram notifier
+func NewTelegramNotifier(config *configure.TelegramConfig) *TelegramNotifier {
+	return &TelegramNotifier{config: config}
+}
+
+// Send sends the message to every chat, split into several messages if it is too long
+func (t *TelegramNotifier) Send(title, message string) error {
+	return t.send(title, message, false)
+}
+
+// SendNotification sends the message of the notification, without sound if it only warns about expiring certificates
+// and silent_cert_warnings is enabled
+func (t *TelegramNotifier) SendNotification(notification notifierTypes.Notification) error {
+	silent := t.config.SilentCertWarnings && isCertWarningOnly(notification)
+	return t.send(notification.Title, notification.Message, silent)
+}
+
+// send sends the title and message as MarkdownV2 messages to every chat, a failed chat does not stop the others
+func (t *TelegramNotifier) send(title, message string, silent bool) error {
+	token := params.NewParameterResolver().ResolveParameters(t.config.BotToken)
+	if token == "" {
+		token = os.Getenv("TELEGRAM_BOT_TOKEN")
+	}
+	if token == "" {
+		return fmt.Errorf("telegram bot token not configured")
+	}
+
+	chatIDs := t.config.ChatIDs
+	if len(chatIDs) == 0 && os.Getenv("TELEGRAM_CHAT_ID") != "" {
+		chatIDs = strings.Split(os.Getenv("TELEGRAM_CHAT_ID"), ",")
+	}
+	if len(chatIDs) == 0 {
+		return fmt.Errorf("telegram chat IDs not configured")
+	}
+
+	apiURL := t.config.APIURL
+	if apiURL == "" {
+		apiURL = telegramAPIURL
+	}
+	url := fmt.Sprintf("%s/bot%s/sendMessage", strings.TrimSuffix(apiURL, "/"), token)
+
+	texts := buildTelegramTexts(title, message)
+	var errs []error
+	for _, chatID := range chatIDs {
+		chatID = strings.TrimSpace(chatID)
+		for i, text := range texts {
+			payload := telegramMessage{
+				ChatID:              chatID,
+				Text:                text,
+				ParseMode:           "MarkdownV2",
+				DisableNotification: silent,
+				LinkPreviewOptions:  telegramLinkPreviewOptions{IsDisabled: true},
+			}
+			if err := sendHTTPRequest(url, "POST", payload, nil, t.config.Retries, t.config.Timeout, false); err != nil {
+				// the request URL holds the bot token, which must not end up in the logs
+				errs = append(errs, fmt.Errorf("chat %s, message %d of %d: %s", chatID, i+1, len(texts), strings.ReplaceAll(err.Error(), token, "***")))
+				break
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// buildTelegramTexts splits the title and message into MarkdownV2 texts within the length limit,
+// the title is shown in bold at the start of the first text
+func buildTelegramTexts(title, message string) []string {
+	// the length limit applies to the parsed text, so the plain text is split before it is escaped
+	chunks := splitTextBy(title+"\n\n"+message, telegramMaxTextLength, func(r rune) int { return utf16.RuneLen(r) })
+
+	texts := make([]string, 0, len(chunks))
+	for i, chunk := range chunks {
+		if i == 0 && strings.HasPrefix(chunk, title) {
+			texts = append(texts, "*"+escapeTelegramMarkdown(title)+"*"+escapeTelegramMarkdown(strings.TrimPrefix(chunk, title)))
+			continue
+		}
+		texts = append(texts, escapeTelegramMarkdown(chunk))
+	}
+	return texts
+}
+
+// isCertWarningOnly checks if the notification only reports certificates that expire soon
+func isCertWarningOnly(notification notifierTypes.Notification) bool {
+	if len(notification.Events) > 0 || len(notification.CertProblems) == 0 {
+		return false
+	}
+	for _, endpoints := range notification.CertProblems {
+		for _, endpoint := range endpoints {
+			if endpoint.IsCertExpired {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// escapeTelegramMarkdown escapes every character with a meaning in Telegram MarkdownV2
+func escapeTelegramMarkdown(s string) string {
+	var escaped strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune("\\_*[]()~`>#+-=|{}.!", r) {
+			escaped.WriteRune('\\')
+		}
+		escaped.WriteRune(r)
+	}
+	return escaped.String()
+}
@@ -0,0 +1,141 @@
+package channels
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+// newTelegramServer starts a test Bot API server that records the messages sent to it
+func newTelegramServer(t *testing.T, messages *[]telegramMessage) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bot123:secret/sendMessage" {
+			t.Errorf("Expected the sendMessage method of the bot, got %s", r.URL.Path)
+		}
+		var message telegramMessage
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Errorf("Failed to decode Telegram message: %v", err)
+		}
+		*messages = append(*messages, message)
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestTelegramNotifier_Send(t *testing.T) {
+	var messages []telegramMessage
+	server := newTelegramServer(t, &messages)
+
+	notifier := NewTelegramNotifier(&configure.TelegramConfig{BotToken: "123:secret", ChatIDs: []string{"-100", "42"}, APIURL: server.URL})
+	if err := notifier.Send("🚨 Alert!", "🔴 Gateway (https://gateway.example.com/health) is down_1."); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	if len(messages) != 2 || messages[0].ChatID != "-100" || messages[1].ChatID != "42" {
+		t.Fatalf("Expected a message to each chat, got %+v", messages)
+	}
+	expected := "*🚨 Alert\\!*\n\n🔴 Gateway \\(https://gateway\\.example\\.com/health\\) is down\\_1\\."
+	if messages[0].Text != expected || messages[0].ParseMode != "MarkdownV2" {
+		t.Errorf("Expected the MarkdownV2 text %q, got %q in %s", expected, messages[0].Text, messages[0].ParseMode)
+	}
+	if messages[0].DisableNotification {
+		t.Error("Expected plain notifications to make a sound")
+	}
+}
+
+func TestTelegramNotifier_SendNotification_SilentCertWarnings(t *testing.T) {
+	warning := notifierTypes.Notification{
+		Title:        "Alert",
+		Message:      "certificate expires soon",
+		CertProblems: map[string][]checker.Endpoint{"Website": {{URL: "https://example.com", CertRemainingDays: 5}}},
+	}
+	expired := warning
+	expired.CertProblems = map[string][]checker.Endpoint{"Website": {{URL: "https://example.com", IsCertExpired: true}}}
+	outage := warning
+	outage.Events = []notifierTypes.Event{{ServiceName: "Gateway", Status: "down", PreviousStatus: "up"}}
+
+	tests := []struct {
+		name         string
+		notification notifierTypes.Notification
+		silent       bool
+	}{
+		{"cert warning", warning, true},
+		{"expired cert", expired, false},
+		{"outage with cert warning", outage, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var messages []telegramMessage
+			server := newTelegramServer(t, &messages)
+
+			notifier := NewTelegramNotifier(&configure.TelegramConfig{BotToken: "123:secret", ChatIDs: []string{"42"}, APIURL: server.URL, SilentCertWarnings: true})
+			if err := notifier.SendNotification(tt.notification); err != nil {
+				t.Fatalf("SendNotification failed: %v", err)
+			}
+			if len(messages) != 1 || messages[0].DisableNotification != tt.silent {
+				t.Errorf("Expected silent to be %v, got %+v", tt.silent, messages)
+			}
+		})
+	}
+}
+
+func TestBuildTelegramTexts_Split(t *testing.T) {
+	message := strings.Repeat("🔴 https://example.com/a_b is down.\n", 300)
+
+	texts := buildTelegramTexts("Title", message)
+
+	if len(texts) < 3 {
+		t.Fatalf("Expected the message to be split, got %d texts", len(texts))
+	}
+	var unescaped strings.Builder
+	for i, text := range texts {
+		// the limit applies to the text without escape characters, counted in UTF-16 code units
+		plain := strings.NewReplacer(`\_`, "_", `\.`, ".", `\*`, "", "*", "").Replace(text)
+		if length := len(utf16.Encode([]rune(plain))); length > telegramMaxTextLength {
+			t.Errorf("Expected text %d to have at most %d code units, got %d", i, telegramMaxTextLength, length)
+		}
+		if strings.HasSuffix(text, `\`) {
+			t.Errorf("Expected text %d not to end in the middle of an escape sequence", i)
+		}
+		unescaped.WriteString(plain)
+	}
+	if unescaped.String() != "Title\n\n"+message {
+		t.Error("Expected the texts to hold the whole message")
+	}
+}
+
+func TestTelegramNotifier_ErrorHidesToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"ok":false,"description":"Bad Request: chat not found"}`, http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	notifier := NewTelegramNotifier(&configure.TelegramConfig{BotToken: "123:secret", ChatIDs: []string{"42"}, APIURL: server.URL})
+	err := notifier.Send("Title", "Message")
+	if err == nil || !strings.Contains(err.Error(), "chat not found") {
+		t.Fatalf("Expected the API error, got %v", err)
+	}
+	if strings.Contains(err.Error(), "secret") {
+		t.Errorf("Expected the bot token to be hidden, got %v", err)
+	}
+}
+
+func TestTelegramNotifier_MissingConfig(t *testing.T) {
+	t.Setenv("TELEGRAM_BOT_TOKEN", "")
+	t.Setenv("TELEGRAM_CHAT_ID", "")
+	if err := NewTelegramNotifier(&configure.TelegramConfig{ChatIDs: []string{"42"}}).Send("Title", "Message"); err == nil {
+		t.Error("Expected an error without a bot token")
+	}
+	if err := NewTelegramNotifier(&configure.TelegramConfig{BotToken: "123:secret"}).Send("Title", "Message"); err == nil {
+		t.Error("Expected an error without chat IDs")
+	}
+}
@@ -2,11 +2,15 @@ package channels
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
 	"crypto/tls"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -53,13 +57,19 @@ func createHTTPClient(timeout int, skipTLSVerify bool) *http.Client {
 
 // SendHTTPRequest sends an HTTP request with retry logic
 func sendHTTPRequest(url string, method string, payload interface{}, headers map[string]string, maxRetries, timeout int, skipTLSVerify bool) error {
+	_, err := sendHTTPRequestForResponse(url, method, payload, headers, maxRetries, timeout, skipTLSVerify)
+	return err
+}
+
+// sendHTTPRequestForResponse sends an HTTP request with retry logic and returns the body of the successful response
+func sendHTTPRequestForResponse(url string, method string, payload interface{}, headers map[string]string, maxRetries, timeout int, skipTLSVerify bool) ([]byte, error) {
 	client := createHTTPClient(timeout, skipTLSVerify)
 
 	var bodyReader io.Reader
 	if payload != nil {
 		jsonData, err := json.Marshal(payload)
 		if err != nil {
-			return fmt.Errorf("failed to marshal payload: %w", err)
+			return nil, fmt.Errorf("failed to marshal payload: %w", err)
 		}
 		bodyReader = bytes.NewBuffer(jsonData)
 	}
@@ -115,7 +125,7 @@ func sendHTTPRequest(url string, method string, payload interface{}, headers map
 
 		// Check if request was successful
 		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-			return nil
+			return body, nil
 		}
 
 		// Handle specific status codes
@@ -125,15 +135,15 @@ func sendHTTPRequest(url string, method string, payload interface{}, headers map
 		case 500, 502, 503, 504: // Server errors - retry
 			lastErr = fmt.Errorf("server error (%d), response: %s", resp.StatusCode, string(body))
 		default: // Client errors - don't retry
-			return fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
 		}
 	}
 
-	return fmt.Errorf("request failed after %d retries, last error: %w", maxRetries+1, lastErr)
+	return nil, fmt.Errorf("request failed after %d retries, last error: %w", maxRetries+1, lastErr)
 }
 
 // SendHTTPRequestWithCustomBody sends an HTTP request with custom body content
-func sendHTTPRequestWithCustomBody(url string, method string, body io.Reader, contentType string, headers map[string]string, maxRetries, timeout int, skipTLSVerify bool) error {
+func sendHTTPRequestWithCustomBody(url string, method string, body []byte, contentType string, headers map[string]string, maxRetries, timeout int, skipTLSVerify bool) error {
 	client := createHTTPClient(timeout, skipTLSVerify)
 
 	var lastErr error
@@ -147,7 +157,8 @@ func sendHTTPRequestWithCustomBody(url string, method string, body io.Reader, co
 			time.Sleep(waitTime)
 		}
 
-		req, err := http.NewRequest(method, url, body)
+		// a new reader per attempt, so retries send the whole body again
+		req, err := http.NewRequest(method, url, bytes.NewReader(body))
 		if err != nil {
 			lastErr = fmt.Errorf("failed to create request: %w", err)
 			continue
@@ -193,3 +204,102 @@ func sendHTTPRequestWithCustomBody(url string, method string, body io.Reader, co
 
 	return fmt.Errorf("request failed after %d retries, last error: %w", maxRetries+1, lastErr)
 }
+
+// truncateText shortens text to at most maxLength runes, ending it with an ellipsis if it was cut
+func truncateText(text string, maxLength int) string {
+	runes := []rune(text)
+	if len(runes) <= maxLength {
+		return text
+	}
+	return string(runes[:maxLength-1]) + "…"
+}
+
+// splitText splits text into chunks of at most maxLength runes, preferring to split at line breaks
+func splitText(text string, maxLength int) []string {
+	return splitTextBy(text, maxLength, func(rune) int { return 1 })
+}
+
+// splitTextBy splits text into chunks of at most maxLength, measuring each rune with runeLength,
+// preferring to split at line breaks
+func splitTextBy(text string, maxLength int, runeLength func(rune) int) []string {
+	var chunks []string
+	var current strings.Builder
+	currentLength := 0
+	for _, line := range strings.SplitAfter(text, "\n") {
+		lineLength := 0
+		for _, r := range line {
+			lineLength += runeLength(r)
+		}
+		// a line that does not fit into the current chunk starts a new one
+		if currentLength > 0 && currentLength+lineLength > maxLength {
+			chunks = append(chunks, current.String())
+			current.Reset()
+			currentLength = 0
+		}
+		for _, r := range line {
+			length := runeLength(r)
+			if currentLength > 0 && currentLength+length > maxLength {
+				chunks = append(chunks, current.String())
+				current.Reset()
+				currentLength = 0
+			}
+			current.WriteRune(r)
+			currentLength += length
+		}
+	}
+	if currentLength > 0 {
+		chunks = append(chunks, current.String())
+	}
+	return chunks
+}
+
+// escapeMarkdown escapes the characters Discord and Teams markdown use for formatting and links
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "~", `\~`, "`", "\\`", "|", `\|`, "[", `\[`, "]", `\]`).Replace(s)
+}
+
+// robotResponse is the response of the DingTalk, Feishu and WeCom robots, which report errors with a status code of 200
+type robotResponse struct {
+	ErrCode int    `json:"errcode"`
+	ErrMsg  string `json:"errmsg"`
+	Code    int    `json:"code"`
+	Msg     string `json:"msg"`
+}
+
+// sendRobotMessage posts a message to a chat robot and checks the error code of its response
+func sendRobotMessage(url string, payload interface{}, maxRetries, timeout int) error {
+	body, err := sendHTTPRequestForResponse(url, "POST", payload, nil, maxRetries, timeout, false)
+	if err != nil {
+		return err
+	}
+
+	var response robotResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("failed to parse response %q: %w", string(body), err)
+	}
+	if response.ErrCode != 0 {
+		return fmt.Errorf("robot returned error %d: %s", response.ErrCode, response.ErrMsg)
+	}
+	if response.Code != 0 {
+		return fmt.Errorf("robot returned error %d: %s", response.Code, response.Msg)
+	}
+	return nil
+}
+
+// signRobotRequest signs a timestamp with the secret of a robot using HMAC-SHA256, with the timestamp and the secret as
+// the string to sign. DingTalk uses the secret as the key and signs the string, Feishu uses the string as the key and signs nothing.
+func signRobotRequest(timestamp int64, secret string, stringAsKey bool) string {
+	stringToSign := fmt.Sprintf("%d\n%s", timestamp, secret)
+	key, data := []byte(secret), []byte(stringToSign)
+	if stringAsKey {
+		key, data = []byte(stringToSign), nil
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// isHTTPURL checks if an endpoint is an HTTP endpoint, other endpoints such as host:port cannot be linked
+func isHTTPURL(url string) bool {
+	return strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://")
+}
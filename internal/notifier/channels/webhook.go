@@ -2,18 +2,24 @@ package channels
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io"
+	"hash"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
 	"text/template"
 	"time"
 
 	"github.com/wcy-dt/ponghub/internal/common/params"
 	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
 )
 
 // WebhookNotifier implements generic webhook notifications
@@ -28,6 +34,18 @@ func NewWebhookNotifier(config *configure.WebhookConfig) *WebhookNotifier {
 
 // Send sends a generic webhook notification with enhanced configuration support
 func (w *WebhookNotifier) Send(title, message string) error {
+	return w.send(title, message, nil)
+}
+
+// SendNotification sends the notification with its highest severity in the severity field of the payload
+func (w *WebhookNotifier) SendNotification(notification notifierTypes.Notification) error {
+	return w.send(notification.Title, notification.Message, map[string]interface{}{
+		"severity": notification.MaxSeverity().String(),
+	})
+}
+
+// send sends the webhook request of a title and message, fields are added to the payload data
+func (w *WebhookNotifier) send(title, message string, fields map[string]interface{}) error {
 	// Create parameter resolver for processing Special Parameters
 	resolver := params.NewParameterResolver()
 
@@ -44,7 +62,7 @@ func (w *WebhookNotifier) Send(title, message string) error {
 	url = resolver.ResolveParameters(url)
 
 	// Prepare the payload
-	payload, contentType, err := w.buildPayload(title, message)
+	payload, contentType, err := w.buildPayload(title, message, fields)
 	if err != nil {
 		return fmt.Errorf("failed to build webhook payload: %v", err)
 	}
@@ -72,7 +90,7 @@ func (w *WebhookNotifier) Send(title, message string) error {
 }
 
 // buildPayload constructs the webhook payload based on configuration
-func (w *WebhookNotifier) buildPayload(title, message string) (interface{}, string, error) {
+func (w *WebhookNotifier) buildPayload(title, message string, fields map[string]interface{}) (interface{}, string, error) {
 	// Create parameter resolver for processing Special Parameters
 	resolver := params.NewParameterResolver()
 
@@ -88,6 +106,9 @@ func (w *WebhookNotifier) buildPayload(title, message string) (interface{}, stri
 		"timestamp": time.Now().Format(time.RFC3339),
 		"service":   "ponghub",
 	}
+	for key, value := range fields {
+		data[key] = value
+	}
 
 	// Check for custom payload configuration first
 	if w.config.CustomPayload != nil {
@@ -306,23 +327,72 @@ func (w *WebhookNotifier) sendWithRetry(url, method string, payload interface{},
 	}
 
 	// Handle different payload types
-	var body io.Reader
+	var body []byte
 	if payload != nil {
 		switch v := payload.(type) {
 		case string:
-			body = strings.NewReader(v)
+			body = []byte(v)
 		default:
 			jsonData, err := json.Marshal(payload)
 			if err != nil {
 				return fmt.Errorf("failed to marshal payload: %w", err)
 			}
-			body = bytes.NewReader(jsonData)
+			body = jsonData
+		}
+	}
+
+	// Sign the exact bytes that are sent
+	if w.config.Signing != nil {
+		if err := w.signBody(headers, body, time.Now()); err != nil {
+			return err
 		}
 	}
 
 	return sendHTTPRequestWithCustomBody(url, method, body, contentType, headers, maxRetries, timeout, w.config.SkipTLSVerify)
 }
 
+// signBody sets the signature and timestamp headers of the body. Like Stripe, the signature covers
+// "<timestamp>.<body>" so receivers can reject replayed requests with an old timestamp, and like GitHub,
+// the signature header holds "<algorithm>=<hex HMAC>".
+func (w *WebhookNotifier) signBody(headers map[string]string, body []byte, now time.Time) error {
+	signing := w.config.Signing
+	secret := params.NewParameterResolver().ResolveParameters(signing.Secret)
+	if secret == "" {
+		secret = os.Getenv("WEBHOOK_SIGNING_SECRET")
+	}
+	if secret == "" {
+		return fmt.Errorf("webhook signing secret not configured")
+	}
+
+	algorithm := strings.ToLower(signing.Algorithm)
+	var newHash func() hash.Hash
+	switch algorithm {
+	case "", "sha256":
+		algorithm, newHash = "sha256", sha256.New
+	case "sha512":
+		newHash = sha512.New
+	default:
+		return fmt.Errorf("unsupported webhook signing algorithm %q", signing.Algorithm)
+	}
+
+	signatureHeader := signing.Header
+	if signatureHeader == "" {
+		signatureHeader = "X-PongHub-Signature"
+	}
+	timestampHeader := signing.TimestampHeader
+	if timestampHeader == "" {
+		timestampHeader = "X-PongHub-Timestamp"
+	}
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	headers[timestampHeader] = timestamp
+	headers[signatureHeader] = algorithm + "=" + hex.EncodeToString(mac.Sum(nil))
+	return nil
+}
+
 // WebhookError represents a webhook-specific error
 type WebhookError struct {
 	StatusCode int
@@ -1,14 +1,23 @@
 package channels
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
 	"encoding/json"
+	"hash"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/alert_status"
 )
 
 // TestWebhookNotifier_BasicSend tests basic webhook functionality
@@ -69,6 +78,35 @@ func TestWebhookNotifier_BasicSend(t *testing.T) {
 	}
 }
 
+// TestWebhookNotifier_Severity tests that notifications send their highest severity
+func TestWebhookNotifier_Severity(t *testing.T) {
+	var receivedPayload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&receivedPayload); err != nil {
+			t.Errorf("Failed to parse JSON: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(&configure.WebhookConfig{URL: server.URL})
+	err := notifier.SendNotification(notifierTypes.Notification{
+		Title:   "Alert",
+		Message: "Message",
+		Events: []notifierTypes.Event{
+			{ServiceName: "Cache", Status: alert_status.DEGRADED},
+			{ServiceName: "Gateway", Status: alert_status.DOWN},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to send webhook: %v", err)
+	}
+
+	if receivedPayload["severity"] != "critical" || receivedPayload["title"] != "Alert" {
+		t.Errorf("Expected the critical severity with the title, got %v", receivedPayload)
+	}
+}
+
 // TestWebhookNotifier_CustomPayload tests the custom payload functionality
 //
 //goland:noinspection DuplicatedCode
@@ -159,6 +197,122 @@ func TestWebhookNotifier_Authentication(t *testing.T) {
 	}
 }
 
+// TestWebhookNotifier_Signing tests that the receiver can verify the signature of the exact body it receives
+func TestWebhookNotifier_Signing(t *testing.T) {
+	t.Setenv("PONGHUB_TEST_WEBHOOK_SECRET", "whsec_test")
+
+	tests := []struct {
+		name            string
+		signing         configure.WebhookSigningConfig
+		newHash         func() hash.Hash
+		signatureHeader string
+		timestampHeader string
+		prefix          string
+	}{
+		{
+			name:            "Defaults",
+			signing:         configure.WebhookSigningConfig{Secret: "{{env(PONGHUB_TEST_WEBHOOK_SECRET)}}"},
+			newHash:         sha256.New,
+			signatureHeader: "X-PongHub-Signature",
+			timestampHeader: "X-PongHub-Timestamp",
+			prefix:          "sha256=",
+		},
+		{
+			name: "SHA-512 with custom headers",
+			signing: configure.WebhookSigningConfig{
+				Secret:          "{{env(PONGHUB_TEST_WEBHOOK_SECRET)}}",
+				Algorithm:       "SHA512",
+				Header:          "X-Signature",
+				TimestampHeader: "X-Signature-Timestamp",
+			},
+			newHash:         sha512.New,
+			signatureHeader: "X-Signature",
+			timestampHeader: "X-Signature-Timestamp",
+			prefix:          "sha512=",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body []byte
+			var header http.Header
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ = io.ReadAll(r.Body)
+				header = r.Header.Clone()
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			signing := tt.signing
+			notifier := NewWebhookNotifier(&configure.WebhookConfig{URL: server.URL, Signing: &signing})
+			if err := notifier.Send("Service down", "Gateway is down"); err != nil {
+				t.Fatalf("Failed to send webhook: %v", err)
+			}
+
+			timestamp := header.Get(tt.timestampHeader)
+			sent, err := strconv.ParseInt(timestamp, 10, 64)
+			if err != nil || time.Since(time.Unix(sent, 0)) > time.Minute {
+				t.Fatalf("Expected a current Unix timestamp, got %q", timestamp)
+			}
+			mac := hmac.New(tt.newHash, []byte("whsec_test"))
+			mac.Write([]byte(timestamp + "."))
+			mac.Write(body)
+			expected := tt.prefix + hex.EncodeToString(mac.Sum(nil))
+			if signature := header.Get(tt.signatureHeader); !hmac.Equal([]byte(signature), []byte(expected)) {
+				t.Errorf("Expected signature %q, got %q", expected, signature)
+			}
+		})
+	}
+}
+
+// TestWebhookNotifier_SigningRetry tests that a retried request sends the same signed body again
+func TestWebhookNotifier_SigningRetry(t *testing.T) {
+	var bodies []string
+	var signatures []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		signatures = append(signatures, r.Header.Get("X-PongHub-Signature"))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(&configure.WebhookConfig{
+		URL:     server.URL,
+		Retries: 1,
+		Signing: &configure.WebhookSigningConfig{Secret: "whsec_test"},
+	})
+	if err := notifier.Send("Service down", "Gateway is down"); err != nil {
+		t.Fatalf("Failed to send webhook: %v", err)
+	}
+
+	if len(bodies) != 2 || bodies[1] == "" || bodies[0] != bodies[1] || signatures[0] != signatures[1] {
+		t.Errorf("Expected the retry to resend the signed body, got bodies %q and signatures %q", bodies, signatures)
+	}
+}
+
+// TestWebhookNotifier_SigningWithoutSecret tests that a request is not sent unsigned when the secret is missing
+func TestWebhookNotifier_SigningWithoutSecret(t *testing.T) {
+	t.Setenv("WEBHOOK_SIGNING_SECRET", "")
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(&configure.WebhookConfig{URL: server.URL, Signing: &configure.WebhookSigningConfig{}})
+	if err := notifier.Send("Test", "Test message"); err == nil {
+		t.Error("Expected an error for a missing signing secret")
+	}
+	if atomic.LoadInt32(&requests) != 0 {
+		t.Error("Expected no unsigned request to be sent")
+	}
+}
+
 // TestWebhookNotifier_ErrorHandling tests error handling and retries
 func TestWebhookNotifier_ErrorHandling(t *testing.T) {
 	// Test server that returns error
@@ -0,0 +1,80 @@
+package channels
+
+import (
+	"fmt"
+	"os"
+	"unicode/utf8"
+
+	"github.com/wcy-dt/ponghub/internal/common/params"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+// Limits of the content of WeCom robot messages in bytes
+const (
+	weComMaxTextBytes     = 2048
+	weComMaxMarkdownBytes = 4096
+)
+
+type (
+	// weComContent is the content of a text or markdown message
+	weComContent struct {
+		Content string `json:"content"`
+	}
+
+	// weComMessage is the payload posted to a WeCom robot
+	weComMessage struct {
+		MsgType  string        `json:"msgtype"`
+		Text     *weComContent `json:"text,omitempty"`
+		Markdown *weComContent `json:"markdown,omitempty"`
+	}
+)
+
+// WeComNotifier implements WeCom group robot notifications
+type WeComNotifier struct {
+	config *configure.WeComConfig
+}
+
+// NewWeComNotifier creates a new WeCom notifier
+func NewWeComNotifier(config *configure.WeComConfig) *WeComNotifier {
+	return &WeComNotifier{config: config}
+}
+
+// Send sends a plain text message, split into several messages if it is too long
+func (w *WeComNotifier) Send(title, message string) error {
+	var messages []weComMessage
+	for _, chunk := range splitTextBy(title+"\n\n"+message, weComMaxTextBytes, utf8.RuneLen) {
+		messages = append(messages, weComMessage{MsgType: "text", Text: &weComContent{Content: chunk}})
+	}
+	return w.postAll(messages)
+}
+
+// SendNotification sends a markdown message with one block per service and incident type
+func (w *WeComNotifier) SendNotification(notification notifierTypes.Notification) error {
+	text := "### " + escapeMarkdown(notification.Title) + "\n" + formatMarkdownSections(notification, "\n")
+
+	var messages []weComMessage
+	for _, chunk := range splitTextBy(text, weComMaxMarkdownBytes, utf8.RuneLen) {
+		messages = append(messages, weComMessage{MsgType: "markdown", Markdown: &weComContent{Content: chunk}})
+	}
+	return w.postAll(messages)
+}
+
+// postAll posts the messages in order to the configured robot
+func (w *WeComNotifier) postAll(messages []weComMessage) error {
+	webhookURL := w.config.WebhookURL
+	if webhookURL == "" {
+		webhookURL = os.Getenv("WECOM_WEBHOOK_URL")
+	}
+	if webhookURL == "" {
+		return fmt.Errorf("wecom webhook URL not configured")
+	}
+	webhookURL = params.NewParameterResolver().ResolveParameters(webhookURL)
+
+	for i, message := range messages {
+		if err := sendRobotMessage(webhookURL, message, w.config.Retries, w.config.Timeout); err != nil {
+			return fmt.Errorf("failed to send message %d of %d: %w", i+1, len(messages), err)
+		}
+	}
+	return nil
+}
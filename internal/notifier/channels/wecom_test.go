@@ -0,0 +1,69 @@
+package channels
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+)
+
+func TestWeComNotifier_SendNotification(t *testing.T) {
+	var messages []weComMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message weComMessage
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Errorf("Failed to decode WeCom message: %v", err)
+		}
+		messages = append(messages, message)
+		_, _ = w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer server.Close()
+
+	notification := notifierTypes.Notification{Title: "🚨 PongHub Service Status Alert"}
+	for i := range 60 {
+		notification.Events = append(notification.Events, notifierTypes.Event{
+			ServiceName: fmt.Sprintf("服务 %d", i),
+			Endpoint:    checker.Endpoint{URL: fmt.Sprintf("https://service-%d.example.com/health", i), StatusCode: 502, AttemptNum: 3},
+			Status:      "down", PreviousStatus: "up",
+		})
+	}
+	notifier := NewWeComNotifier(&configure.WeComConfig{WebhookURL: server.URL})
+	if err := notifier.SendNotification(notification); err != nil {
+		t.Fatalf("SendNotification failed: %v", err)
+	}
+
+	if len(messages) < 2 {
+		t.Fatalf("Expected the markdown to be split into several messages, got %d", len(messages))
+	}
+	var content strings.Builder
+	for i, message := range messages {
+		if message.MsgType != "markdown" || message.Markdown == nil {
+			t.Fatalf("Expected markdown messages, got %+v", message)
+		}
+		if length := len(message.Markdown.Content); length > weComMaxMarkdownBytes {
+			t.Errorf("Expected message %d to have at most %d bytes, got %d", i, weComMaxMarkdownBytes, length)
+		}
+		content.WriteString(message.Markdown.Content)
+	}
+	if !strings.HasPrefix(content.String(), "### 🚨 PongHub Service Status Alert\n") || !strings.Contains(content.String(), "**🔴 服务 59 is down**") {
+		t.Errorf("Expected the messages to hold the whole notification, got:\n%s", content.String())
+	}
+}
+
+func TestWeComNotifier_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"errcode":93000,"errmsg":"invalid webhook url"}`))
+	}))
+	defer server.Close()
+
+	notifier := NewWeComNotifier(&configure.WeComConfig{WebhookURL: server.URL})
+	if err := notifier.Send("Title", "Message"); err == nil || !strings.Contains(err.Error(), "invalid webhook url") {
+		t.Errorf("Expected the error of the robot, got %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package notifier
+
+import (
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/alert_status"
+)
+
+// markImpactedEvents sets ImpactedBy on the events of services that depend, directly or not, on a service that is down.
+// Recoveries are never marked, so they are notified like any other recovery.
+func markImpactedEvents(events []notifierTypes.Event, state notifierTypes.State, cfg *configure.Configure) []notifierTypes.Event {
+	dependsOn := make(map[string][]string)
+	for _, service := range cfg.Services {
+		dependsOn[service.Name] = service.DependsOn
+	}
+
+	isDown := make(map[string]bool)
+	for serviceName, serviceState := range state {
+		for _, endpointState := range serviceState {
+			if alert_status.ParseAlertStatus(endpointState.Status) == alert_status.DOWN {
+				isDown[serviceName] = true
+				break
+			}
+		}
+	}
+
+	for i := range events {
+		if events[i].IsRecovery() {
+			continue
+		}
+		events[i].ImpactedBy = findRootCause(events[i].ServiceName, dependsOn, isDown)
+	}
+	return events
+}
+
+// findRootCause returns the first down service the given service depends on that does not depend on a down service itself,
+// or an empty string if none of its dependencies is down. The dependencies are checked for cycles when the config is loaded.
+func findRootCause(serviceName string, dependsOn map[string][]string, isDown map[string]bool) string {
+	for _, parent := range dependsOn[serviceName] {
+		if rootCause := findRootCause(parent, dependsOn, isDown); rootCause != "" {
+			return rootCause
+		}
+		if isDown[parent] {
+			return parent
+		}
+	}
+	return ""
+}
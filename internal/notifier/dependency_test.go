@@ -0,0 +1,106 @@
+package notifier
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+)
+
+// newDependencyConfig builds a config where Billing and Orders depend on Gateway, and Invoices on Billing
+func newDependencyConfig() *configure.Configure {
+	return &configure.Configure{
+		Services: []configure.Service{
+			{Name: "Gateway"},
+			{Name: "Billing", DependsOn: []string{"Gateway"}},
+			{Name: "Orders", DependsOn: []string{"Gateway"}},
+			{Name: "Invoices", DependsOn: []string{"Billing"}},
+		},
+	}
+}
+
+// newDependencyResult builds a check result with one endpoint per service and the given statuses
+func newDependencyResult(statuses map[string]chk_result.CheckResult) []checker.Service {
+	var checkResult []checker.Service
+	for _, name := range []string{"Gateway", "Billing", "Orders", "Invoices"} {
+		checkResult = append(checkResult, checker.Service{
+			Name:      name,
+			Endpoints: []checker.Endpoint{{URL: "https://" + strings.ToLower(name) + ".example.com", Status: statuses[name]}},
+		})
+	}
+	return checkResult
+}
+
+func TestMarkImpactedEvents(t *testing.T) {
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	cfg := newDependencyConfig()
+	state := make(notifierTypes.State)
+
+	// the gateway and everything behind it goes down
+	checkResult := newDependencyResult(map[string]chk_result.CheckResult{
+		"Gateway": chk_result.NONE, "Billing": chk_result.NONE, "Orders": chk_result.DEGRADED, "Invoices": chk_result.NONE,
+	})
+	events := markImpactedEvents(UpdateState(state, checkResult, cfg, start), state, cfg)
+
+	impactedBy := make(map[string]string)
+	for _, event := range events {
+		impactedBy[event.ServiceName] = event.ImpactedBy
+	}
+	expected := map[string]string{"Gateway": "", "Billing": "Gateway", "Orders": "Gateway", "Invoices": "Gateway"}
+	for service, rootCause := range expected {
+		if impactedBy[service] != rootCause {
+			t.Errorf("Expected %s to be impacted by %q, got %q", service, rootCause, impactedBy[service])
+		}
+	}
+
+	// once the gateway is back, the reminders of a remaining outage point at the next root cause
+	cfg.Notifications = &configure.NotificationConfig{ParsedRenotifyInterval: time.Hour}
+	checkResult = newDependencyResult(map[string]chk_result.CheckResult{
+		"Gateway": chk_result.ALL, "Billing": chk_result.NONE, "Orders": chk_result.ALL, "Invoices": chk_result.NONE,
+	})
+	events = markImpactedEvents(UpdateState(state, checkResult, cfg, start.Add(time.Hour)), state, cfg)
+
+	impactedBy = make(map[string]string)
+	for _, event := range events {
+		impactedBy[event.ServiceName] = event.ImpactedBy
+	}
+	expected = map[string]string{"Gateway": "", "Billing": "", "Orders": "", "Invoices": "Billing"}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected two recoveries and two reminders, got %+v", events)
+	}
+	for service, rootCause := range expected {
+		if impactedBy[service] != rootCause {
+			t.Errorf("Expected %s to be impacted by %q, got %q", service, rootCause, impactedBy[service])
+		}
+	}
+}
+
+func TestGenerateNotificationMessage_Impacted(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	events := []notifierTypes.Event{
+		{ServiceName: "Gateway", Endpoint: checker.Endpoint{URL: "https://gateway.example.com"}, Status: "down", PreviousStatus: "up"},
+		{ServiceName: "Billing", Endpoint: checker.Endpoint{URL: "https://billing.example.com"}, Status: "down", PreviousStatus: "up", ImpactedBy: "Gateway"},
+		{ServiceName: "Orders", Endpoint: checker.Endpoint{URL: "https://orders.example.com/a"}, Status: "down", PreviousStatus: "up", ImpactedBy: "Gateway"},
+		{ServiceName: "Orders", Endpoint: checker.Endpoint{URL: "https://orders.example.com/b"}, Status: "down", PreviousStatus: "up", ImpactedBy: "Gateway"},
+		{ServiceName: "Search", Endpoint: checker.Endpoint{URL: "https://search.example.com"}, Status: "down", PreviousStatus: "up", ImpactedBy: "Index"},
+	}
+
+	message := loadMessageTemplates(nil).apply("", notifierTypes.Notification{Events: events, GeneratedAt: now}).Message
+
+	if !strings.Contains(message, "⛓️ Impacted Services: Billing, Orders") {
+		t.Errorf("Expected the impacted services to be folded into the gateway alert, got:\n%s", message)
+	}
+	if strings.Contains(message, "📋 Service: Billing") || strings.Contains(message, "📋 Service: Orders") {
+		t.Errorf("Expected folded services not to get their own section, got:\n%s", message)
+	}
+	if !strings.Contains(message, "🔗 IMPACTED SERVICES:") || !strings.Contains(message, "Impacted by: Index") {
+		t.Errorf("Expected a service impacted by an earlier outage to be listed with its root cause, got:\n%s", message)
+	}
+	if !strings.Contains(message, "Unavailable Endpoints: 1\n") || !strings.Contains(message, "Impacted Endpoints: 4\n") {
+		t.Errorf("Expected the summary to count impacted endpoints separately, got:\n%s", message)
+	}
+}
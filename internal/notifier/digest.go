@@ -0,0 +1,245 @@
+package notifier
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/logger"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/alert_status"
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+)
+
+type (
+	// digestTemplateData is the data of the digest template
+	digestTemplateData struct {
+		// Title is the title of the digest
+		Title string
+		// Since and Until delimit the period of the digest
+		Since time.Time
+		Until time.Time
+		// Services holds the endpoints with changes of the alert state, Certificates the certificate problems
+		Services     []digestTemplateService
+		Certificates []digestTemplateService
+		// Alerts counts the alerts collected in the period
+		Alerts int
+	}
+
+	// digestTemplateService groups the endpoints of a service in a digest section
+	digestTemplateService struct {
+		Name      string
+		Endpoints []digestTemplateEndpoint
+	}
+
+	// digestTemplateEndpoint is a collected endpoint with the failures of the period found in the log
+	digestTemplateEndpoint struct {
+		notifierTypes.DigestAlert
+		// Downtime and DegradedTime total the time the endpoint was failing or degraded in the period
+		Downtime     time.Duration
+		DegradedTime time.Duration
+		// FirstFailure and LastFailure are the first and last failed checks of the period, zero without failures
+		FirstFailure time.Time
+		LastFailure  time.Time
+	}
+)
+
+// hasPendingDigests checks if any notification method has collected alerts that are not sent yet
+func hasPendingDigests(states notifierTypes.ChannelStates) bool {
+	for _, state := range states {
+		if len(state.DigestAlerts) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// digest returns the digest configuration of the method
+func (nm *NotificationManager) digest(method string) (configure.DigestConfig, bool) {
+	digest, ok := nm.digests[strings.ToLower(method)]
+	return digest, ok
+}
+
+// collectDigest adds the alerts of the notification to the pending digest of the method,
+// and returns the notification with the alerts that are still sent right away
+func (nm *NotificationManager) collectDigest(method string, digest configure.DigestConfig, notification notifierTypes.Notification) notifierTypes.Notification {
+	if nm.channels == nil {
+		nm.channels = make(notifierTypes.ChannelStates)
+	}
+	key := strings.ToLower(method)
+	state := nm.channels[key]
+	if state.DigestSince == "" {
+		state.DigestSince = notification.GeneratedAt.Format(time.RFC3339)
+	}
+
+	if !digest.CertificatesOnly {
+		for _, event := range notification.Events {
+			alert := findDigestAlert(&state, event.ServiceName, event.Endpoint.URL, false)
+			alert.Status = string(event.Status)
+			alert.Alerts++
+		}
+		notification.Events = nil
+	}
+
+	// map order is random, so the certificate problems are collected by service
+	certServiceNames := make([]string, 0, len(notification.CertProblems))
+	for serviceName := range notification.CertProblems {
+		certServiceNames = append(certServiceNames, serviceName)
+	}
+	slices.Sort(certServiceNames)
+	for _, serviceName := range certServiceNames {
+		for _, endpoint := range notification.CertProblems[serviceName] {
+			alert := findDigestAlert(&state, serviceName, endpoint.URL, true)
+			alert.CertRemainingDays = endpoint.CertRemainingDays
+			alert.IsCertExpired = endpoint.IsCertExpired
+			alert.Alerts++
+		}
+	}
+	notification.CertProblems = nil
+
+	if len(state.DigestAlerts) == 0 {
+		state.DigestSince = ""
+	}
+	nm.setChannelState(key, state)
+	return notification
+}
+
+// findDigestAlert returns the collected alert of the endpoint, adding it if it was not collected yet
+func findDigestAlert(state *notifierTypes.ChannelState, serviceName, url string, certificate bool) *notifierTypes.DigestAlert {
+	for i := range state.DigestAlerts {
+		alert := &state.DigestAlerts[i]
+		if alert.ServiceName == serviceName && alert.URL == url && alert.Certificate == certificate {
+			return alert
+		}
+	}
+	state.DigestAlerts = append(state.DigestAlerts, notifierTypes.DigestAlert{ServiceName: serviceName, URL: url, Certificate: certificate})
+	return &state.DigestAlerts[len(state.DigestAlerts)-1]
+}
+
+// SendDigests sends the pending digests whose interval has passed since their first alert,
+// with the failures of the endpoints taken from the check log. Digests that fail are sent again on the next run.
+func (nm *NotificationManager) SendDigests(history logger.Logger, now time.Time) {
+	// the alerts of methods whose digest was removed from the configuration are dropped
+	for method, state := range nm.channels {
+		if _, ok := nm.digest(method); !ok && len(state.DigestAlerts) > 0 {
+			nm.clearDigest(method)
+		}
+	}
+
+	templates := nm.templates
+	if templates == nil {
+		templates = loadMessageTemplates(nil)
+	}
+	for i, service := range nm.services {
+		method := nm.getServiceName(i)
+		digest, ok := nm.digest(method)
+		state := nm.channels[strings.ToLower(method)]
+		if !ok || len(state.DigestAlerts) == 0 {
+			continue
+		}
+		since := parseStateTime(state.DigestSince, now)
+		if now.Sub(since) < digest.ParsedInterval {
+			continue
+		}
+		if reason := nm.heldBackReason(method, now); reason != "" {
+			log.Printf("Holding back the digest of %s because of its %s", method, reason)
+			continue
+		}
+
+		title, message := templates.renderDigest(newDigestTemplateData(state, history, since, now))
+		if err := service.Send(title, message); err != nil {
+			log.Printf("Failed to send the digest via %s, retrying on the next run: %v", method, err)
+			continue
+		}
+		log.Printf("Successfully sent the digest of %d alert(s) via %s", countDigestAlerts(state), method)
+		nm.clearDigest(method)
+		nm.recordSend(method, now)
+	}
+}
+
+// clearDigest removes the pending digest of the method from its state
+func (nm *NotificationManager) clearDigest(method string) {
+	key := strings.ToLower(method)
+	state := nm.channels[key]
+	state.DigestSince = ""
+	state.DigestAlerts = nil
+	nm.setChannelState(key, state)
+}
+
+// countDigestAlerts counts the alerts collected in the digest
+func countDigestAlerts(state notifierTypes.ChannelState) int {
+	count := 0
+	for _, alert := range state.DigestAlerts {
+		count += alert.Alerts
+	}
+	return count
+}
+
+// newDigestTemplateData groups the collected alerts by service in collection order,
+// and totals the failures of their endpoints between since and until from the check log
+func newDigestTemplateData(state notifierTypes.ChannelState, history logger.Logger, since, until time.Time) digestTemplateData {
+	data := digestTemplateData{Since: since, Until: until, Alerts: countDigestAlerts(state)}
+	data.Title = fmt.Sprintf("📬 PongHub Digest: %d alert(s)", data.Alerts)
+
+	for _, alert := range state.DigestAlerts {
+		endpoint := digestTemplateEndpoint{DigestAlert: alert}
+		if alert.Certificate {
+			data.Certificates = appendDigestEndpoint(data.Certificates, alert.ServiceName, endpoint)
+			continue
+		}
+		endpoint.Downtime, endpoint.DegradedTime, endpoint.FirstFailure, endpoint.LastFailure =
+			summarizeHistory(history[alert.ServiceName].Endpoints[alert.URL], since, until)
+		data.Services = appendDigestEndpoint(data.Services, alert.ServiceName, endpoint)
+	}
+	return data
+}
+
+// appendDigestEndpoint adds the endpoint to the section of its service, adding the service if needed
+func appendDigestEndpoint(services []digestTemplateService, serviceName string, endpoint digestTemplateEndpoint) []digestTemplateService {
+	for i := range services {
+		if services[i].Name == serviceName {
+			services[i].Endpoints = append(services[i].Endpoints, endpoint)
+			return services
+		}
+	}
+	return append(services, digestTemplateService{Name: serviceName, Endpoints: []digestTemplateEndpoint{endpoint}})
+}
+
+// summarizeHistory totals the time between since and until in which the endpoint was failing or degraded,
+// every check counts until the next check, and finds the first and last failed checks
+func summarizeHistory(history logger.History, since, until time.Time) (downtime, degradedTime time.Duration, firstFailure, lastFailure time.Time) {
+	for i, entry := range history {
+		start, err := time.Parse(time.RFC3339, entry.Time)
+		if err != nil {
+			continue
+		}
+		end := until
+		if i+1 < len(history) {
+			if next, err := time.Parse(time.RFC3339, history[i+1].Time); err == nil && next.Before(until) {
+				end = next
+			}
+		}
+		if start.Before(since) {
+			start = since
+		}
+		if !end.After(start) {
+			continue
+		}
+
+		// the checks are classified like the alert state, so a partial success is not downtime
+		switch alert_status.FromCheckResult(chk_result.CheckResult(entry.Status)) {
+		case alert_status.DOWN:
+			downtime += end.Sub(start)
+			if firstFailure.IsZero() {
+				firstFailure = start
+			}
+			lastFailure = start
+		case alert_status.DEGRADED:
+			degradedTime += end.Sub(start)
+		}
+	}
+	return downtime, degradedTime, firstFailure, lastFailure
+}
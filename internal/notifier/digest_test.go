@@ -0,0 +1,163 @@
+package notifier
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/logger"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+)
+
+func TestSendNotifications_CertificateDigest(t *testing.T) {
+	var titles, messages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("Failed to decode payload: %v", err)
+		}
+		titles = append(titles, payload["title"].(string))
+		messages = append(messages, payload["message"].(string))
+	}))
+	defer server.Close()
+
+	cfg := newAlertConfig(30*time.Minute, 1, 1)
+	cfg.Notifications = &configure.NotificationConfig{
+		Enabled:                true,
+		Methods:                []string{"webhook"},
+		ParsedRenotifyInterval: 30 * time.Minute,
+		Webhook:                &configure.WebhookConfig{URL: server.URL},
+		Digests: map[string]configure.DigestConfig{
+			"webhook": {Interval: "daily", ParsedInterval: 24 * time.Hour, CertificatesOnly: true},
+		},
+	}
+	statePath := filepath.Join(t.TempDir(), "notify_state.json")
+	channelStatePath := filepath.Join(t.TempDir(), "notify_channels.json")
+	logPath := filepath.Join(t.TempDir(), "ponghub_log.json")
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	// the certificate problem is due every run, the outage is sent right away
+	for i := 0; i <= 48; i++ {
+		status := chk_result.ALL
+		if i == 0 {
+			status = chk_result.NONE
+		}
+		checkResult := []checker.Service{{
+			Name:      "API",
+			Endpoints: []checker.Endpoint{{URL: "https://api.example.com", Status: status, IsHTTPS: true, CertRemainingDays: 3}},
+		}}
+		sendNotifications(checkResult, checkResult, cfg, statePath, channelStatePath, logPath, start.Add(time.Duration(i)*30*time.Minute))
+	}
+
+	if len(titles) != 3 {
+		t.Fatalf("Expected the outage, the recovery and one digest, got %d: %v", len(titles), titles)
+	}
+	if titles[0] != "🚨 PongHub Service Status Alert" || strings.Contains(messages[0], "CERTIFICATE") {
+		t.Errorf("Expected the outage without the certificate problem, got %q: %q", titles[0], messages[0])
+	}
+	if titles[2] != "📬 PongHub Digest: 49 alert(s)" || !strings.Contains(messages[2], "Days Remaining: 3") {
+		t.Errorf("Expected a daily digest of the certificate problem, got %q: %q", titles[2], messages[2])
+	}
+}
+
+func TestSendNotifications_DigestIncludesCurrentRound(t *testing.T) {
+	var messages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("Failed to decode payload: %v", err)
+		}
+		messages = append(messages, payload["message"].(string))
+	}))
+	defer server.Close()
+
+	cfg := newAlertConfig(0, 1, 1)
+	cfg.Notifications = &configure.NotificationConfig{
+		Enabled: true,
+		Methods: []string{"webhook"},
+		Webhook: &configure.WebhookConfig{URL: server.URL},
+		Digests: map[string]configure.DigestConfig{
+			"webhook": {Interval: "hourly", ParsedInterval: time.Hour},
+		},
+	}
+	statePath := filepath.Join(t.TempDir(), "notify_state.json")
+	channelStatePath := filepath.Join(t.TempDir(), "notify_channels.json")
+	logPath := filepath.Join(t.TempDir(), "ponghub_log.json")
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	// the log is written after the notifications, so the failure of the last round is not in it yet
+	for i, status := range []chk_result.CheckResult{chk_result.DEGRADED, chk_result.ALL, chk_result.NONE} {
+		now := start.Add(time.Duration(i) * 30 * time.Minute)
+		checkResult := []checker.Service{{
+			Name:      "API",
+			StartTime: now.Add(-time.Minute).Format(time.RFC3339),
+			Endpoints: []checker.Endpoint{{URL: "https://api.example.com", Status: status, StartTime: now.Add(-time.Minute).Format(time.RFC3339)}},
+		}}
+		sendNotifications(checkResult, checkResult, cfg, statePath, channelStatePath, logPath, now)
+	}
+
+	if len(messages) != 1 {
+		t.Fatalf("Expected one digest, got %d", len(messages))
+	}
+	if !strings.Contains(messages[0], "Last Failure: 2025-01-01 10:59:00") {
+		t.Errorf("Expected the failure of the current round in the digest, got %q", messages[0])
+	}
+}
+
+func TestSummarizeHistory(t *testing.T) {
+	history := logger.History{
+		{Time: "2025-01-01T09:00:00Z", Status: "none"},
+		{Time: "2025-01-01T10:30:00Z", Status: "all"},
+		{Time: "2025-01-01T11:00:00Z", Status: "none"},
+		{Time: "2025-01-01T11:30:00Z", Status: "degraded"},
+		{Time: "2025-01-01T12:00:00Z", Status: "part"},
+		{Time: "2025-01-01T12:30:00Z", Status: "all"},
+	}
+	since := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	until := since.Add(3 * time.Hour)
+
+	downtime, degradedTime, firstFailure, lastFailure := summarizeHistory(history, since, until)
+	if downtime != time.Hour || degradedTime != 30*time.Minute {
+		t.Errorf("Expected 1h down and 30m degraded, got %s and %s", downtime, degradedTime)
+	}
+	if !firstFailure.Equal(since) || !lastFailure.Equal(since.Add(time.Hour)) {
+		t.Errorf("Expected failures from 10:00 to 11:00, got %s to %s", firstFailure, lastFailure)
+	}
+}
+
+func TestRenderDigest(t *testing.T) {
+	since := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	state := notifierTypes.ChannelState{
+		DigestSince: since.Format(time.RFC3339),
+		DigestAlerts: []notifierTypes.DigestAlert{
+			{ServiceName: "API", URL: "https://api.example.com", Status: "up", Alerts: 2},
+			{ServiceName: "API", URL: "https://api.example.com", Certificate: true, IsCertExpired: true, Alerts: 1},
+		},
+	}
+	history := logger.Logger{"API": {Endpoints: logger.Endpoints{"https://api.example.com": {
+		{Time: "2025-01-01T10:15:00Z", Status: "none"},
+		{Time: "2025-01-01T10:45:00Z", Status: "all"},
+	}}}}
+
+	title, message := loadMessageTemplates(nil).renderDigest(newDigestTemplateData(state, history, since, since.Add(time.Hour)))
+	if title != "📬 PongHub Digest: 3 alert(s)" {
+		t.Errorf("Unexpected title %q", title)
+	}
+	for _, expected := range []string{
+		"Alerts: 2, latest status: up",
+		"Total Downtime: 30m0s",
+		"First Failure: 2025-01-01 10:15:00",
+		"❌ Certificate Status: EXPIRED",
+	} {
+		if !strings.Contains(message, expected) {
+			t.Errorf("Expected the digest to contain %q, got %q", expected, message)
+		}
+	}
+}
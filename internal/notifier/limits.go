@@ -0,0 +1,280 @@
+package notifier
+
+import (
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/severity"
+)
+
+// hasQueuedAlerts checks if any notification method holds back alerts until it can send again
+func hasQueuedAlerts(states notifierTypes.ChannelStates) bool {
+	for _, state := range states {
+		if state.HasQueuedAlerts() {
+			return true
+		}
+	}
+	return false
+}
+
+// limits returns the quiet hours and rate limit of the method
+func (nm *NotificationManager) limits(method string) (configure.LimitsConfig, bool) {
+	limits, ok := nm.limitConfigs[strings.ToLower(method)]
+	return limits, ok
+}
+
+// heldBackReason returns why the method cannot send at the given time, or an empty string if it can
+func (nm *NotificationManager) heldBackReason(method string, now time.Time) string {
+	limits, ok := nm.limits(method)
+	if !ok {
+		return ""
+	}
+	if limits.QuietHours != nil && limits.QuietHours.IsActive(now) {
+		return "quiet hours"
+	}
+	if limits.MaxPerHour > 0 && len(recentSends(nm.channels[strings.ToLower(method)], now)) >= limits.MaxPerHour {
+		return "rate limit"
+	}
+	return ""
+}
+
+// throttle holds back the alerts of the notification while the method is in its quiet hours or above its rate limit,
+// alerts of the bypass severity are sent anyway. Once the method can send again, the queued alerts are sent with the new ones
+// and stay queued until the send succeeds, this also retries the alerts of earlier failed sends in order.
+// It returns whether anything is left to send.
+func (nm *NotificationManager) throttle(method string, notification notifierTypes.Notification) (notifierTypes.Notification, bool) {
+	if nm.channels == nil {
+		nm.channels = make(notifierTypes.ChannelStates)
+	}
+	key := strings.ToLower(method)
+	state := nm.channels[key]
+
+	reason := nm.heldBackReason(method, notification.GeneratedAt)
+	if reason == "" {
+		// a new slice, so the notification does not share the backing array of the persisted queue
+		notification.Events = slices.Concat(state.QueuedEvents, notification.Events)
+		notification.CertProblems = mergeCertProblems(state.QueuedCertProblems, notification.CertProblems)
+		return notification, true
+	}
+
+	limits, _ := nm.limits(method)
+	bypassSeverity := severity.Severity(limits.BypassSeverity)
+	isBypassing := func(level severity.Severity) bool {
+		return bypassSeverity != "" && level.AtLeast(bypassSeverity)
+	}
+	held := 0
+	var events []notifierTypes.Event
+	for _, event := range notification.Events {
+		if isBypassing(notification.EventSeverity(event)) {
+			events = append(events, event)
+			continue
+		}
+		state.QueuedEvents = queueEvent(state.QueuedEvents, event)
+		held++
+	}
+	certProblems := make(map[string][]checker.Endpoint)
+	for serviceName, endpoints := range notification.CertProblems {
+		for _, endpoint := range endpoints {
+			if isBypassing(notification.CertSeverity(serviceName, endpoint)) {
+				certProblems[serviceName] = append(certProblems[serviceName], endpoint)
+				continue
+			}
+			state.QueuedCertProblems = mergeCertProblems(state.QueuedCertProblems, map[string][]checker.Endpoint{serviceName: {endpoint}})
+			held++
+		}
+	}
+	nm.setChannelState(key, state)
+	if held > 0 {
+		log.Printf("Holding back %d alert(s) for %s because of its %s", held, method, reason)
+	}
+
+	notification.Events = events
+	notification.CertProblems = certProblems
+	return notification, len(events) > 0 || len(certProblems) > 0
+}
+
+// FlushQueues sends the queued alerts of the methods that can send again, with the data of the given notification.
+// Alerts that fail to send are queued again for the next run.
+func (nm *NotificationManager) FlushQueues(notification notifierTypes.Notification) {
+	for i, service := range nm.services {
+		method := nm.getServiceName(i)
+		key := strings.ToLower(method)
+		state := nm.channels[key]
+		if !state.HasQueuedAlerts() || nm.failed[key] || nm.heldBackReason(method, notification.GeneratedAt) != "" {
+			continue
+		}
+
+		queued := notification
+		queued.Events = state.QueuedEvents
+		queued.CertProblems = state.QueuedCertProblems
+		if err := sendToService(service, nm.render(method, queued)); err != nil {
+			log.Printf("Failed to send the queued alerts via %s, retrying on the next run: %v", method, err)
+			continue
+		}
+		log.Printf("Successfully sent the queued alerts via %s", method)
+		nm.dequeue(method, queued)
+		nm.recordSend(method, notification.GeneratedAt)
+	}
+}
+
+// dequeue drops the alerts of a sent notification from the queue of the method, alerts still held back stay queued
+func (nm *NotificationManager) dequeue(method string, sent notifierTypes.Notification) {
+	key := strings.ToLower(method)
+	state, ok := nm.channels[key]
+	if !ok || !state.HasQueuedAlerts() {
+		return
+	}
+	var events []notifierTypes.Event
+	for _, event := range state.QueuedEvents {
+		if !isQueued(sent.Events, event) {
+			events = append(events, event)
+		}
+	}
+	certProblems := make(map[string][]checker.Endpoint)
+	for serviceName, endpoints := range state.QueuedCertProblems {
+		for _, endpoint := range endpoints {
+			if !hasCertProblem(sent.CertProblems[serviceName], endpoint.URL) {
+				certProblems[serviceName] = append(certProblems[serviceName], endpoint)
+			}
+		}
+	}
+	state.QueuedEvents = events
+	state.QueuedCertProblems = nil
+	if len(certProblems) > 0 {
+		state.QueuedCertProblems = certProblems
+	}
+	nm.setChannelState(key, state)
+}
+
+// hasCertProblem checks if the endpoints contain the certificate problem of the URL
+func hasCertProblem(endpoints []checker.Endpoint, url string) bool {
+	for _, endpoint := range endpoints {
+		if endpoint.URL == url {
+			return true
+		}
+	}
+	return false
+}
+
+// requeue queues the alerts of a notification the method failed to send, so they are retried on the next run
+// instead of being lost
+func (nm *NotificationManager) requeue(method string, notification notifierTypes.Notification) {
+	if nm.channels == nil {
+		nm.channels = make(notifierTypes.ChannelStates)
+	}
+	if nm.failed == nil {
+		nm.failed = make(map[string]bool)
+	}
+	key := strings.ToLower(method)
+	nm.failed[key] = true
+
+	state := nm.channels[key]
+	for _, event := range notification.Events {
+		if !isQueued(state.QueuedEvents, event) {
+			state.QueuedEvents = queueEvent(state.QueuedEvents, event)
+		}
+	}
+	state.QueuedCertProblems = mergeCertProblems(state.QueuedCertProblems, notification.CertProblems)
+	nm.setChannelState(key, state)
+}
+
+// isQueued checks if the event is already queued, as the queued alerts are sent along with the new ones
+func isQueued(queued []notifierTypes.Event, event notifierTypes.Event) bool {
+	for _, e := range queued {
+		if e.ServiceName == event.ServiceName && e.Endpoint.URL == event.Endpoint.URL &&
+			e.Status == event.Status && e.IsReminder == event.IsReminder {
+			return true
+		}
+	}
+	return false
+}
+
+// recordSend counts a message sent by the method at the given time for its rate limit
+func (nm *NotificationManager) recordSend(method string, now time.Time) {
+	limits, ok := nm.limits(method)
+	if !ok || limits.MaxPerHour <= 0 {
+		return
+	}
+	if nm.channels == nil {
+		nm.channels = make(notifierTypes.ChannelStates)
+	}
+	key := strings.ToLower(method)
+	state := nm.channels[key]
+	state.SentAt = append(recentSends(state, now), now.Format(time.RFC3339))
+	nm.setChannelState(key, state)
+}
+
+// setChannelState stores the state of the method, dropping it once it is empty
+func (nm *NotificationManager) setChannelState(key string, state notifierTypes.ChannelState) {
+	if state.IsEmpty() {
+		delete(nm.channels, key)
+		return
+	}
+	nm.channels[key] = state
+}
+
+// recentSends returns the send times of the state within the hour before now
+func recentSends(state notifierTypes.ChannelState, now time.Time) []string {
+	var recent []string
+	for _, sentAt := range state.SentAt {
+		if now.Sub(parseStateTime(sentAt, now)) < time.Hour {
+			recent = append(recent, sentAt)
+		}
+	}
+	return recent
+}
+
+// queueEvent adds the event to the queued events. Reminders of endpoints with a queued event are dropped,
+// and other events replace the queued reminders of their endpoint, so long quiet hours do not pile up reminders.
+func queueEvent(queued []notifierTypes.Event, event notifierTypes.Event) []notifierTypes.Event {
+	isSameEndpoint := func(e notifierTypes.Event) bool {
+		return e.ServiceName == event.ServiceName && e.Endpoint.URL == event.Endpoint.URL
+	}
+	if event.IsReminder {
+		for _, e := range queued {
+			if isSameEndpoint(e) {
+				return queued
+			}
+		}
+		return slices.Concat(queued, []notifierTypes.Event{event})
+	}
+
+	kept := make([]notifierTypes.Event, 0, len(queued)+1)
+	for _, e := range queued {
+		if !e.IsReminder || !isSameEndpoint(e) {
+			kept = append(kept, e)
+		}
+	}
+	return append(kept, event)
+}
+
+// mergeCertProblems adds the certificate problems to the queued ones, newer problems of an endpoint replace older ones
+func mergeCertProblems(queued, certProblems map[string][]checker.Endpoint) map[string][]checker.Endpoint {
+	if len(queued) == 0 {
+		return certProblems
+	}
+	merged := make(map[string][]checker.Endpoint, len(queued))
+	for serviceName, endpoints := range queued {
+		merged[serviceName] = append([]checker.Endpoint(nil), endpoints...)
+	}
+	for serviceName, endpoints := range certProblems {
+		for _, endpoint := range endpoints {
+			replaced := false
+			for i := range merged[serviceName] {
+				if merged[serviceName][i].URL == endpoint.URL {
+					merged[serviceName][i] = endpoint
+					replaced = true
+				}
+			}
+			if !replaced {
+				merged[serviceName] = append(merged[serviceName], endpoint)
+			}
+		}
+	}
+	return merged
+}
@@ -0,0 +1,197 @@
+package notifier
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/alert_status"
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+)
+
+func TestSendNotifications_QuietHours(t *testing.T) {
+	var titles, messages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("Failed to decode payload: %v", err)
+		}
+		titles = append(titles, payload["title"].(string))
+		messages = append(messages, payload["message"].(string))
+	}))
+	defer server.Close()
+
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Skipf("Timezone data not available: %v", err)
+	}
+	cfg := newAlertConfig(0, 1, 1)
+	cfg.Notifications = &configure.NotificationConfig{
+		Enabled: true,
+		Methods: []string{"webhook"},
+		Webhook: &configure.WebhookConfig{URL: server.URL},
+		Limits: map[string]configure.LimitsConfig{
+			"webhook": {QuietHours: &configure.QuietHoursConfig{
+				ParsedStart:    22 * time.Hour,
+				ParsedEnd:      7 * time.Hour,
+				ParsedTimezone: shanghai,
+			}},
+		},
+	}
+	statePath := filepath.Join(t.TempDir(), "notify_state.json")
+	channelStatePath := filepath.Join(t.TempDir(), "notify_channels.json")
+	logPath := filepath.Join(t.TempDir(), "ponghub_log.json")
+	start := time.Date(2025, 1, 1, 23, 0, 0, 0, shanghai)
+
+	// the endpoint is degraded and recovers during the night, both alerts wait for 07:00
+	for i := 0; i <= 17; i++ {
+		status := chk_result.ALL
+		if i < 4 {
+			status = chk_result.DEGRADED
+		}
+		checkResult := newCheckResult(status)
+		sendNotifications(checkResult, checkResult, cfg, statePath, channelStatePath, logPath, start.Add(time.Duration(i)*30*time.Minute))
+		if i < 16 && len(titles) > 0 {
+			t.Fatalf("Expected nothing to be sent before 07:00, got %v at run %d", titles, i)
+		}
+	}
+
+	if len(titles) != 1 {
+		t.Fatalf("Expected the queued alerts in one notification, got %d: %v", len(titles), titles)
+	}
+	if !strings.Contains(messages[0], "DEGRADED SERVICES") || !strings.Contains(messages[0], "RECOVERED SERVICES") {
+		t.Errorf("Expected the degradation and the recovery, got %q", messages[0])
+	}
+}
+
+func TestNotificationManager_RateLimit(t *testing.T) {
+	service := &richService{}
+	manager := &NotificationManager{
+		config: &configure.NotificationConfig{Enabled: true},
+		limitConfigs: map[string]configure.LimitsConfig{
+			"email": {MaxPerHour: 2, BypassSeverity: "critical"},
+		},
+	}
+	manager.addService("email", service)
+
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	alert := func(minutes int, status alert_status.AlertStatus, isReminder bool) notifierTypes.Notification {
+		return notifierTypes.Notification{
+			GeneratedAt: start.Add(time.Duration(minutes) * time.Minute),
+			Events: []notifierTypes.Event{{
+				ServiceName: "API",
+				Endpoint:    checker.Endpoint{URL: "https://api.example.com"},
+				Status:      status,
+				IsReminder:  isReminder,
+			}},
+		}
+	}
+	manager.SendNotification(alert(0, alert_status.DEGRADED, false))
+	manager.SendNotification(alert(10, alert_status.DEGRADED, true))
+	manager.SendNotification(alert(20, alert_status.DEGRADED, true))
+	manager.SendNotification(alert(30, alert_status.DEGRADED, true))
+	if len(service.notifications) != 2 {
+		t.Fatalf("Expected 2 notifications within the hour, got %d", len(service.notifications))
+	}
+	if queued := manager.channels["email"].QueuedEvents; len(queued) != 1 {
+		t.Errorf("Expected the reminders to be queued once, got %d queued events", len(queued))
+	}
+
+	manager.SendNotification(alert(40, alert_status.DOWN, false))
+	if len(service.notifications) != 3 {
+		t.Fatalf("Expected the critical alert to bypass the rate limit, got %d notifications", len(service.notifications))
+	}
+
+	manager.FlushQueues(notifierTypes.Notification{GeneratedAt: start.Add(50 * time.Minute)})
+	if len(service.notifications) != 3 {
+		t.Fatalf("Expected the queue to wait for the rate limit, got %d notifications", len(service.notifications))
+	}
+	// the critical alert counts too, so the queue waits until the second notification is an hour old
+	manager.FlushQueues(notifierTypes.Notification{GeneratedAt: start.Add(71 * time.Minute)})
+	if len(service.notifications) != 4 || len(service.notifications[3].Events) != 1 {
+		t.Fatalf("Expected the queued reminder to be sent once the rate limit allows it, got %d notifications", len(service.notifications))
+	}
+	if manager.channels["email"].HasQueuedAlerts() {
+		t.Error("Expected the queue to be empty once it is sent")
+	}
+}
+
+func TestNotificationManager_QueueKeptOnFailure(t *testing.T) {
+	service := &richService{}
+	manager := &NotificationManager{
+		config: &configure.NotificationConfig{Enabled: true},
+		limitConfigs: map[string]configure.LimitsConfig{
+			"email": {MaxPerHour: 1},
+		},
+	}
+	manager.addService("email", service)
+
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	alert := func(minutes int, url string) notifierTypes.Notification {
+		return notifierTypes.Notification{
+			GeneratedAt: start.Add(time.Duration(minutes) * time.Minute),
+			Events: []notifierTypes.Event{{
+				ServiceName: "API",
+				Endpoint:    checker.Endpoint{URL: url},
+				Status:      alert_status.DEGRADED,
+			}},
+		}
+	}
+	manager.SendNotification(alert(0, "https://api.example.com/a"))
+	manager.SendNotification(alert(10, "https://api.example.com/b"))
+	if queued := manager.channels["email"].QueuedEvents; len(queued) != 1 {
+		t.Fatalf("Expected the second alert to be queued, got %d queued events", len(queued))
+	}
+
+	// the rate limit allows sending again, but the send fails
+	service.err = errors.New("connection refused")
+	manager.SendNotification(alert(70, "https://api.example.com/c"))
+	manager.FlushQueues(notifierTypes.Notification{GeneratedAt: start.Add(70 * time.Minute)})
+	if queued := manager.channels["email"].QueuedEvents; len(queued) != 2 {
+		t.Fatalf("Expected the queued and the new alert to stay queued after the failure, got %d queued events", len(queued))
+	}
+
+	// the next run starts with a new manager and the persisted channel states
+	service.err = nil
+	manager = &NotificationManager{
+		config:       manager.config,
+		limitConfigs: manager.limitConfigs,
+		channels:     manager.channels,
+	}
+	manager.addService("email", service)
+	manager.FlushQueues(notifierTypes.Notification{GeneratedAt: start.Add(80 * time.Minute)})
+	if len(service.notifications) != 2 || len(service.notifications[1].Events) != 2 {
+		t.Fatalf("Expected the queued alerts to be sent on the next run, got %d notifications", len(service.notifications))
+	}
+	if manager.channels["email"].HasQueuedAlerts() {
+		t.Error("Expected the queue to be empty once it is sent")
+	}
+}
+
+func TestNotificationManager_ThrottleCopiesQueue(t *testing.T) {
+	queued := make([]notifierTypes.Event, 1, 4)
+	queued[0] = notifierTypes.Event{ServiceName: "API", Endpoint: checker.Endpoint{URL: "https://api.example.com"}, Status: alert_status.DOWN}
+	manager := &NotificationManager{
+		limitConfigs: map[string]configure.LimitsConfig{"email": {MaxPerHour: 1}},
+		channels:     notifierTypes.ChannelStates{"email": {QueuedEvents: queued}},
+	}
+
+	notification, ok := manager.throttle("email", notifierTypes.Notification{
+		Events: []notifierTypes.Event{{ServiceName: "Website", Endpoint: checker.Endpoint{URL: "https://example.com"}, Status: alert_status.DOWN}},
+	})
+	if !ok || len(notification.Events) != 2 {
+		t.Fatalf("Expected the queued and the new event, got %+v", notification.Events)
+	}
+	notification.Events[0].ServiceName = "Changed"
+	if manager.channels["email"].QueuedEvents[0].ServiceName != "API" || queued[:2][1].ServiceName != "" {
+		t.Error("Expected the notification not to share the backing array of the queue")
+	}
+}
@@ -7,19 +7,34 @@ import (
 
 	"github.com/wcy-dt/ponghub/internal/notifier/channels"
 	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
 )
 
 // NotificationManager manages multiple notification services
 type NotificationManager struct {
 	services []NotificationService
-	config   *configure.NotificationConfig
+	// methods holds the notification method of every service
+	methods   []string
+	config    *configure.NotificationConfig
+	templates *messageTemplates
+	// router filters the alerts of every method, nil sends every alert to every method
+	router *notificationRouter
+	// digests maps the methods that batch their alerts to their digest
+	digests map[string]configure.DigestConfig
+	// limitConfigs maps the methods with quiet hours or a rate limit to their limits
+	limitConfigs map[string]configure.LimitsConfig
+	// channels is the state of the notification methods persisted between runs
+	channels notifierTypes.ChannelStates
+	// failed holds the methods that failed to send in this run, their queued alerts wait for the next run
+	failed map[string]bool
 }
 
 // NewNotificationManager creates a new notification manager
 func NewNotificationManager(config *configure.NotificationConfig) *NotificationManager {
 	manager := &NotificationManager{
-		config:   config,
-		services: make([]NotificationService, 0),
+		config:    config,
+		services:  make([]NotificationService, 0),
+		templates: loadMessageTemplates(nil),
 	}
 
 	// If no notification config is provided, use default method
@@ -31,7 +46,7 @@ func NewNotificationManager(config *configure.NotificationConfig) *NotificationM
 			Methods: []string{"default"},
 			Default: defaultConfig,
 		}
-		manager.services = append(manager.services, channels.NewDefaultNotifier(defaultConfig))
+		manager.addService("default", channels.NewDefaultNotifier(defaultConfig))
 		return manager
 	}
 
@@ -40,6 +55,8 @@ func NewNotificationManager(config *configure.NotificationConfig) *NotificationM
 		return &NotificationManager{}
 	}
 
+	manager.templates = loadMessageTemplates(config.Templates)
+
 	// If no methods are specified but notifications are enabled, use default
 	if len(config.Methods) == 0 {
 		log.Println("Notifications enabled but no methods specified, using default GitHub Actions notification")
@@ -47,7 +64,7 @@ func NewNotificationManager(config *configure.NotificationConfig) *NotificationM
 			config.Default = &configure.DefaultConfig{Enabled: true}
 		}
 		config.Methods = []string{"default"}
-		manager.services = append(manager.services, channels.NewDefaultNotifier(config.Default))
+		manager.addService("default", channels.NewDefaultNotifier(config.Default))
 		return manager
 	}
 
@@ -58,14 +75,54 @@ func NewNotificationManager(config *configure.NotificationConfig) *NotificationM
 			if config.Default == nil {
 				config.Default = &configure.DefaultConfig{Enabled: true}
 			}
-			manager.services = append(manager.services, channels.NewDefaultNotifier(config.Default))
+			manager.addService(method, channels.NewDefaultNotifier(config.Default))
 		case "email":
 			if config.Email != nil {
-				manager.services = append(manager.services, channels.NewEmailNotifier(config.Email))
+				manager.addService(method, channels.NewEmailNotifier(config.Email))
 			}
 		case "webhook":
 			if config.Webhook != nil {
-				manager.services = append(manager.services, channels.NewWebhookNotifier(config.Webhook))
+				manager.addService(method, channels.NewWebhookNotifier(config.Webhook))
+			}
+		case "slack":
+			if config.Slack != nil {
+				manager.addService(method, channels.NewSlackNotifier(config.Slack))
+			}
+		case "discord":
+			if config.Discord != nil {
+				manager.addService(method, channels.NewDiscordNotifier(config.Discord))
+			}
+		case "teams":
+			if config.Teams != nil {
+				manager.addService(method, channels.NewTeamsNotifier(config.Teams))
+			}
+		case "telegram":
+			if config.Telegram != nil {
+				manager.addService(method, channels.NewTelegramNotifier(config.Telegram))
+			}
+		case "pagerduty":
+			if config.PagerDuty != nil {
+				manager.addService(method, channels.NewPagerDutyNotifier(config.PagerDuty))
+			}
+		case "dingtalk":
+			if config.DingTalk != nil {
+				manager.addService(method, channels.NewDingTalkNotifier(config.DingTalk))
+			}
+		case "feishu", "lark":
+			if config.Feishu != nil {
+				manager.addService(method, channels.NewFeishuNotifier(config.Feishu))
+			}
+		case "wecom":
+			if config.WeCom != nil {
+				manager.addService(method, channels.NewWeComNotifier(config.WeCom))
+			}
+		case "ntfy":
+			if config.Ntfy != nil {
+				manager.addService(method, channels.NewNtfyNotifier(config.Ntfy))
+			}
+		case "gotify":
+			if config.Gotify != nil {
+				manager.addService(method, channels.NewGotifyNotifier(config.Gotify))
 			}
 		default:
 			log.Printf("Unknown notification method: %s", method)
@@ -75,8 +132,15 @@ func NewNotificationManager(config *configure.NotificationConfig) *NotificationM
 	return manager
 }
 
-// SendNotification sends notification through all configured services
-func (nm *NotificationManager) SendNotification(title, message string) {
+// addService adds the service of a notification method
+func (nm *NotificationManager) addService(method string, service NotificationService) {
+	nm.services = append(nm.services, service)
+	nm.methods = append(nm.methods, method)
+}
+
+// SendNotification sends notification through all configured services.
+// The alerts a method fails to send are queued on the method and retried on the next run.
+func (nm *NotificationManager) SendNotification(notification notifierTypes.Notification) {
 	if nm.config == nil || !nm.config.Enabled || len(nm.services) == 0 {
 		log.Println("Notifications are disabled or no services configured")
 		return
@@ -86,23 +150,77 @@ func (nm *NotificationManager) SendNotification(title, message string) {
 
 	var failedServices []string
 	for i, service := range nm.services {
-		if err := service.Send(title, message); err != nil {
-			serviceName := nm.getServiceName(i)
+		serviceName := nm.getServiceName(i)
+		routed, ok := nm.router.route(serviceName, notification)
+		if !ok {
+			log.Printf("No alerts routed to %s, skipping", serviceName)
+			continue
+		}
+		if digest, ok := nm.digest(serviceName); ok {
+			routed = nm.collectDigest(serviceName, digest, routed)
+			if len(routed.Events) == 0 && len(routed.CertProblems) == 0 {
+				log.Printf("Collected the alerts for the digest of %s", serviceName)
+				continue
+			}
+		}
+		if routed, ok = nm.throttle(serviceName, routed); !ok {
+			continue
+		}
+		if err := sendToService(service, nm.render(serviceName, routed)); err != nil {
 			log.Printf("Failed to send notification via %s: %v", serviceName, err)
 			failedServices = append(failedServices, serviceName)
+			nm.requeue(serviceName, routed)
 		} else {
-			serviceName := nm.getServiceName(i)
 			log.Printf("Successfully sent notification via %s", serviceName)
+			nm.dequeue(serviceName, routed)
+			nm.recordSend(serviceName, notification.GeneratedAt)
 		}
 	}
 
 	if len(failedServices) > 0 {
-		log.Printf("Failed to send notifications via: %s", strings.Join(failedServices, ", "))
+		log.Printf("Failed to send notifications via: %s, retrying on the next run", strings.Join(failedServices, ", "))
+	}
+}
+
+// ResolveIncidents closes the incidents of the fixed certificates and removed endpoints of the notification
+// with the services that track incidents
+func (nm *NotificationManager) ResolveIncidents(notification notifierTypes.Notification) {
+	for i, service := range nm.services {
+		incidentService, ok := service.(IncidentService)
+		if !ok {
+			continue
+		}
+		serviceName := nm.getServiceName(i)
+		if err := incidentService.ResolveIncidents(notification); err != nil {
+			log.Printf("Failed to resolve incidents via %s: %v", serviceName, err)
+			continue
+		}
+		log.Printf("Successfully resolved incidents via %s", serviceName)
 	}
 }
 
+// sendToService sends the notification formatted by the service if it supports it, or as plain text
+func sendToService(service NotificationService, notification notifierTypes.Notification) error {
+	if richService, ok := service.(RichNotificationService); ok {
+		return richService.SendNotification(notification)
+	}
+	return service.Send(notification.Title, notification.Message)
+}
+
+// render renders the title and message of the notification with the templates of the method,
+// a manager without templates sends the notification as is
+func (nm *NotificationManager) render(method string, notification notifierTypes.Notification) notifierTypes.Notification {
+	if nm.templates == nil {
+		return notification
+	}
+	return nm.templates.apply(method, notification)
+}
+
 // getServiceName returns the name of the service at the given index
 func (nm *NotificationManager) getServiceName(index int) string {
+	if index < len(nm.methods) {
+		return nm.methods[index]
+	}
 	if index < len(nm.config.Methods) {
 		return nm.config.Methods[index]
 	}
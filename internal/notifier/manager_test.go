@@ -0,0 +1,134 @@
+package notifier
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/alert_status"
+)
+
+// plainService records the plain text notifications it receives
+type plainService struct {
+	titles []string
+}
+
+func (s *plainService) Send(title, _ string) error {
+	s.titles = append(s.titles, title)
+	return nil
+}
+
+// richService records the notifications it formats itself, or fails with err if it is set
+type richService struct {
+	plainService
+	notifications []notifierTypes.Notification
+	err           error
+}
+
+func (s *richService) SendNotification(notification notifierTypes.Notification) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.notifications = append(s.notifications, notification)
+	return nil
+}
+
+func TestNotificationManager_SendNotification(t *testing.T) {
+	plain, rich := &plainService{}, &richService{}
+	manager := &NotificationManager{
+		config:   &configure.NotificationConfig{Enabled: true, Methods: []string{"plain", "rich"}},
+		services: []NotificationService{plain, rich},
+	}
+
+	manager.SendNotification(notifierTypes.Notification{Title: "Alert", Message: "Message"})
+
+	if len(plain.titles) != 1 || plain.titles[0] != "Alert" {
+		t.Errorf("Expected the plain service to get the text notification, got %v", plain.titles)
+	}
+	if len(rich.notifications) != 1 || len(rich.titles) != 0 {
+		t.Errorf("Expected the rich service to format the notification itself, got %d rich and %d plain", len(rich.notifications), len(rich.titles))
+	}
+}
+
+func TestNewNotificationManager_RichChannels(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *configure.NotificationConfig
+	}{
+		{"slack", &configure.NotificationConfig{Slack: &configure.SlackConfig{WebhookURL: "https://hooks.slack.com/services/T000/B000/XXX"}}},
+		{"discord", &configure.NotificationConfig{Discord: &configure.DiscordConfig{WebhookURL: "https://discord.com/api/webhooks/000/XXX"}}},
+		{"teams", &configure.NotificationConfig{Teams: &configure.TeamsConfig{WebhookURL: "https://example.webhook.office.com/webhookb2/XXX"}}},
+		{"telegram", &configure.NotificationConfig{Telegram: &configure.TelegramConfig{ChatIDs: []string{"42"}}}},
+		{"pagerduty", &configure.NotificationConfig{PagerDuty: &configure.PagerDutyConfig{RoutingKey: "R0UT1NGK3Y"}}},
+		{"dingtalk", &configure.NotificationConfig{DingTalk: &configure.DingTalkConfig{}}},
+		{"feishu", &configure.NotificationConfig{Feishu: &configure.FeishuConfig{}}},
+		{"lark", &configure.NotificationConfig{Feishu: &configure.FeishuConfig{}}},
+		{"wecom", &configure.NotificationConfig{WeCom: &configure.WeComConfig{}}},
+		{"ntfy", &configure.NotificationConfig{Ntfy: &configure.NtfyConfig{Topic: "ponghub"}}},
+		{"gotify", &configure.NotificationConfig{Gotify: &configure.GotifyConfig{ServerURL: "https://gotify.example.com"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.Enabled = true
+			tt.config.Methods = []string{tt.name}
+			manager := NewNotificationManager(tt.config)
+
+			if len(manager.services) != 1 {
+				t.Fatalf("Expected a %s service, got %d services", tt.name, len(manager.services))
+			}
+			if _, ok := manager.services[0].(RichNotificationService); !ok {
+				t.Errorf("Expected the %s service to format its notifications", tt.name)
+			}
+		})
+	}
+}
+
+func TestNotificationManager_RetryFailedMethod(t *testing.T) {
+	slack, email := &richService{err: errors.New("status code 500")}, &richService{}
+	config := &configure.NotificationConfig{Enabled: true}
+	newManager := func(channels notifierTypes.ChannelStates) *NotificationManager {
+		manager := &NotificationManager{config: config, channels: channels}
+		manager.addService("slack", slack)
+		manager.addService("email", email)
+		return manager
+	}
+	alert := func(status, previousStatus alert_status.AlertStatus) notifierTypes.Notification {
+		return notifierTypes.Notification{Events: []notifierTypes.Event{{
+			ServiceName:    "API",
+			Endpoint:       checker.Endpoint{URL: "https://api.example.com"},
+			Status:         status,
+			PreviousStatus: previousStatus,
+		}}}
+	}
+
+	manager := newManager(nil)
+	manager.SendNotification(alert(alert_status.DOWN, alert_status.UP))
+	manager.FlushQueues(notifierTypes.Notification{})
+	if len(email.notifications) != 1 || len(slack.notifications) != 0 {
+		t.Fatalf("Expected only email to send the outage, got %d email and %d slack notifications", len(email.notifications), len(slack.notifications))
+	}
+	if queued := manager.channels["slack"].QueuedEvents; len(queued) != 1 {
+		t.Fatalf("Expected the failed outage to be queued for slack, got %d queued events", len(queued))
+	}
+
+	// on the next run slack works again and receives the outage before the recovery
+	slack.err = nil
+	manager = newManager(manager.channels)
+	manager.SendNotification(alert(alert_status.UP, alert_status.DOWN))
+	if len(email.notifications) != 2 || len(email.notifications[1].Events) != 1 {
+		t.Fatalf("Expected email to only send the recovery, got %d notifications", len(email.notifications))
+	}
+	if len(slack.notifications) != 1 {
+		t.Fatalf("Expected slack to send one notification, got %d", len(slack.notifications))
+	}
+	events := slack.notifications[0].Events
+	if len(events) != 2 || events[0].Status != alert_status.DOWN || !events[1].IsRecovery() {
+		t.Errorf("Expected slack to send the outage and then the recovery, got %+v", events)
+	}
+	if manager.channels["slack"].HasQueuedAlerts() {
+		t.Error("Expected the queue of slack to be empty once it is sent")
+	}
+}
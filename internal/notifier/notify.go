@@ -1,16 +1,16 @@
 package notifier
 
 import (
-	"fmt"
 	"log"
 	"os"
-	"strings"
 	"time"
 
+	"github.com/wcy-dt/ponghub/internal/common"
 	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
 	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/logger"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
 	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
-	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
 )
 
 // NotificationService defines the interface for notification services
@@ -18,20 +18,33 @@ type NotificationService interface {
 	Send(title, message string) error
 }
 
-// WriteNotifications sends notifications based on the service check results
-func WriteNotifications(checkResult []checker.Service, certNotifyDays int) {
-	statusNoneEndpoints := collectUnavailableEndpoints(checkResult)
-	certProblemEndpoints := collectCertProblemEndpoints(checkResult, certNotifyDays)
+// RichNotificationService is implemented by notification services that format the notification from its data
+// instead of sending the plain text message
+type RichNotificationService interface {
+	SendNotification(notification notifierTypes.Notification) error
+}
 
-	if len(statusNoneEndpoints) == 0 && len(certProblemEndpoints) == 0 {
-		// if no endpoints have issues, do nothing
-		return
-	}
+// IncidentService is implemented by notification services that keep an incident open for every problem,
+// they are told about the fixed certificates and removed endpoints that end a problem without an event
+type IncidentService interface {
+	ResolveIncidents(notification notifierTypes.Notification) error
+}
+
+// WriteNotifications writes the report of the endpoints with problems to the notify file at notifyPath.
+// latestResult holds the latest result of every service, so services not checked in this round are still reported.
+// The file is removed once no endpoint has a problem.
+func WriteNotifications(latestResult []checker.Service, cfg *configure.Configure, notifyPath string) {
+	statusNoneEndpoints := collectUnavailableEndpoints(latestResult)
+	degradedEndpoints := collectDegradedEndpoints(latestResult)
+	certProblemEndpoints := collectCertProblemEndpoints(latestResult, cfg.CertNotifyDays)
 
-	notifyPath := default_config.GetNotifyPath()
 	if err := removeExistingNotifyFile(notifyPath); err != nil {
 		return
 	}
+	if len(statusNoneEndpoints) == 0 && len(degradedEndpoints) == 0 && len(certProblemEndpoints) == 0 {
+		// if no endpoints have issues, the report of earlier problems is gone
+		return
+	}
 
 	f, err := os.Create(notifyPath)
 	if err != nil {
@@ -44,92 +57,115 @@ func WriteNotifications(checkResult []checker.Service, certNotifyDays int) {
 		}
 	}()
 
-	writeNotificationReport(f, statusNoneEndpoints, certProblemEndpoints)
+	var templates *configure.TemplatesConfig
+	if cfg.Notifications != nil {
+		templates = cfg.Notifications.Templates
+	}
+	report := loadMessageTemplates(templates).renderReport(newReportTemplateData(latestResult, certProblemEndpoints, time.Now()))
+	writeToFile(f, report)
 }
 
-// SendNotifications sends notifications through various channels using the notification manager
-func SendNotifications(checkResult []checker.Service, certNotifyDays int, notificationConfig *configure.NotificationConfig) {
-	statusNoneEndpoints := collectUnavailableEndpoints(checkResult)
-	certProblemEndpoints := collectCertProblemEndpoints(checkResult, certNotifyDays)
+// SendNotifications sends notifications through various channels using the notification manager.
+// Notifications are driven by the alert state persisted between runs: an endpoint is notified when it starts alerting,
+// again every renotify_interval while it keeps alerting, and once more when it recovers.
+// checkResult holds the services checked in this round, latestResult the latest result of every service.
+// The alert state is kept at statePath, the state of the notification methods at channelStatePath,
+// and the check log at logPath is the recent history of the endpoints.
+func SendNotifications(checkResult, latestResult []checker.Service, cfg *configure.Configure, statePath, channelStatePath, logPath string) {
+	sendNotifications(checkResult, latestResult, cfg, statePath, channelStatePath, logPath, time.Now())
+}
 
-	if len(statusNoneEndpoints) == 0 && len(certProblemEndpoints) == 0 {
-		log.Println("No service issues found, skipping notifications")
+// sendNotifications updates the alert state at statePath and notifies its changes, with the check log at logPath
+// as the recent history of the endpoints. The pending digests of the notification methods are kept at channelStatePath.
+func sendNotifications(checkResult, latestResult []checker.Service, cfg *configure.Configure, statePath, channelStatePath, logPath string, now time.Time) {
+	state, err := common.ReadNotifyState(statePath)
+	if err != nil {
+		log.Printf("Error loading notification state from %s: %v", statePath, err)
 		return
 	}
+	state, removedState := FilterState(state, latestResult)
 
-	// Create notification manager
-	manager := NewNotificationManager(notificationConfig)
-	if !manager.IsEnabled() {
-		log.Println("Notification manager is not enabled or no services configured")
-		return
+	var renotifyInterval time.Duration
+	if cfg.Notifications != nil {
+		renotifyInterval = cfg.Notifications.ParsedRenotifyInterval
 	}
+	events := markImpactedEvents(UpdateState(state, checkResult, cfg, now), state, cfg)
+	certProblemEndpoints, certRecoveries := filterCertProblems(state, collectCertProblemEndpoints(checkResult, cfg.CertNotifyDays), checkResult, renotifyInterval, now)
 
-	// Generate notification content
-	title := "🚨 PongHub Service Status Alert"
-	message := generateNotificationMessage(statusNoneEndpoints, certProblemEndpoints)
-
-	// Send notifications
-	manager.SendNotification(title, message)
-}
-
-// generateNotificationMessage creates a formatted message for notifications
-func generateNotificationMessage(statusNoneEndpoints, certProblemEndpoints map[string][]checker.Endpoint) string {
-	var message strings.Builder
-
-	currentTime := time.Now().Format("2006-01-02 15:04:05")
-	message.WriteString(fmt.Sprintf("Generated at: %s\n\n", currentTime))
+	// the state is saved even if nothing is sent, so enabling notifications later does not report old incidents
+	defer func() {
+		if err := common.WriteNotifyState(state, statePath); err != nil {
+			log.Printf("Error saving notification state to %s: %v", statePath, err)
+		}
+	}()
 
-	// Add unavailable services section
-	if len(statusNoneEndpoints) > 0 {
-		message.WriteString("🔴 UNAVAILABLE SERVICES:\n")
-		message.WriteString(strings.Repeat("=", 30) + "\n")
+	// the channel state only holds the pending digests, queued alerts and rate limits of the methods,
+	// so notifications are still sent if it is lost
+	channelStates, err := common.ReadChannelStates(channelStatePath)
+	if err != nil {
+		log.Printf("Error loading notification channel state from %s: %v", channelStatePath, err)
+		channelStates = make(notifierTypes.ChannelStates)
+	}
 
-		for serviceName, endpoints := range statusNoneEndpoints {
-			message.WriteString(fmt.Sprintf("\n📋 Service: %s\n", serviceName))
-			for _, endpoint := range endpoints {
-				message.WriteString(fmt.Sprintf("  • URL: %s\n", endpoint.URL))
-				message.WriteString(fmt.Sprintf("    Method: %s\n", endpoint.Method))
-				if endpoint.StatusCode > 0 {
-					message.WriteString(fmt.Sprintf("    Status Code: %d\n", endpoint.StatusCode))
-				}
-				message.WriteString(fmt.Sprintf("    Attempts: %d/%d successful\n", endpoint.SuccessNum, endpoint.AttemptNum))
-				if len(endpoint.FailureDetails) > 0 {
-					message.WriteString(fmt.Sprintf("    Last Error: %s\n", endpoint.FailureDetails[len(endpoint.FailureDetails)-1]))
-				}
-			}
-		}
+	hasResolvedIncidents := len(certRecoveries) > 0 || len(removedState) > 0
+	if len(events) == 0 && len(certProblemEndpoints) == 0 && !hasResolvedIncidents && !hasPendingDigests(channelStates) && !hasQueuedAlerts(channelStates) {
+		log.Println("No service status changes found, skipping notifications")
+		return
 	}
 
-	// Add certificate issues section
-	if len(certProblemEndpoints) > 0 {
-		message.WriteString("\n🔐 CERTIFICATE ISSUES:\n")
-		message.WriteString(strings.Repeat("=", 30) + "\n")
+	// Create notification manager
+	manager := NewNotificationManager(cfg.Notifications)
+	if !manager.IsEnabled() {
+		log.Println("Notification manager is not enabled or no services configured")
+		return
+	}
 
-		for serviceName, endpoints := range certProblemEndpoints {
-			message.WriteString(fmt.Sprintf("\n📋 Service: %s\n", serviceName))
-			for _, endpoint := range endpoints {
-				message.WriteString(fmt.Sprintf("  • URL: %s\n", endpoint.URL))
-				if endpoint.IsCertExpired {
-					message.WriteString("    ❌ Certificate Status: EXPIRED\n")
-				} else {
-					message.WriteString("    ⚠️ Certificate Status: EXPIRES SOON\n")
-				}
-				message.WriteString(fmt.Sprintf("    Days Remaining: %d\n", endpoint.CertRemainingDays))
-			}
+	// the history is only shown by some channels, so notifications are still sent without it.
+	// The log is written after the notifications, so the checks of this round are merged in
+	history, err := common.ReadLogs(logPath)
+	if err != nil {
+		log.Printf("Error loading logs from %s: %v", logPath, err)
+		history = make(logger.Logger)
+	}
+	history = common.MergeLogs(history, checkResult, cfg.MaxLogDays)
+
+	// the title and message are rendered by the manager with the templates of every channel
+	notification := notifierTypes.Notification{
+		Events:        events,
+		CertProblems:  certProblemEndpoints,
+		GeneratedAt:   now,
+		StatusPageURL: cfg.Notifications.StatusPageURL,
+		History:       history,
+		Severities:    newServiceSeverities(cfg.Services),
+		// problems that ended without a recovery event only close the incidents of the methods tracking them
+		CertRecoveries: certRecoveries,
+		Removed:        removedState,
+	}
+
+	// Send notifications, the routes decide which methods receive which alerts, methods with a digest collect them
+	// until their digest is due, and methods in their quiet hours or above their rate limit queue them
+	manager.router = newNotificationRouter(cfg)
+	manager.digests = cfg.Notifications.Digests
+	manager.limitConfigs = cfg.Notifications.Limits
+	manager.channels = channelStates
+	defer func() {
+		if err := common.WriteChannelStates(manager.channels, channelStatePath); err != nil {
+			log.Printf("Error saving notification channel state to %s: %v", channelStatePath, err)
 		}
+	}()
+	if len(events) > 0 || len(certProblemEndpoints) > 0 {
+		manager.SendNotification(notification)
 	}
+	if hasResolvedIncidents {
+		manager.ResolveIncidents(notification)
+	}
+	manager.FlushQueues(notification)
+	manager.SendDigests(history, now)
+}
 
-	// Add summary
-	unavailableCount := countEndpoints(statusNoneEndpoints)
-	certIssueCount := countEndpoints(certProblemEndpoints)
-
-	message.WriteString("\n📊 SUMMARY:\n")
-	message.WriteString(strings.Repeat("=", 30) + "\n")
-	message.WriteString(fmt.Sprintf("Unavailable Endpoints: %d\n", unavailableCount))
-	message.WriteString(fmt.Sprintf("Certificate Issues: %d\n", certIssueCount))
-	message.WriteString(fmt.Sprintf("Total Issues: %d\n", unavailableCount+certIssueCount))
-
-	return message.String()
+// formatDuration formats a duration for notifications with second precision
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Second).String()
 }
 
 // collectUnavailableEndpoints finds all endpoints with status NONE
@@ -145,11 +181,28 @@ func collectUnavailableEndpoints(checkResult []checker.Service) map[string][]che
 	return statusNoneEndpoints
 }
 
+// collectDegradedEndpoints finds all endpoints with status DEGRADED
+func collectDegradedEndpoints(checkResult []checker.Service) map[string][]checker.Endpoint {
+	degradedEndpoints := make(map[string][]checker.Endpoint)
+	for _, serviceResult := range checkResult {
+		for _, endpointResult := range serviceResult.Endpoints {
+			if endpointResult.Status == chk_result.DEGRADED {
+				degradedEndpoints[serviceResult.Name] = append(degradedEndpoints[serviceResult.Name], endpointResult)
+			}
+		}
+	}
+	return degradedEndpoints
+}
+
 // collectCertProblemEndpoints finds all endpoints whose certificates are expired or expiring soon
 func collectCertProblemEndpoints(checkResult []checker.Service, certNotifyDays int) map[string][]checker.Endpoint {
 	certProblemEndpoints := make(map[string][]checker.Endpoint)
 	for _, serviceResult := range checkResult {
 		for _, endpointResult := range serviceResult.Endpoints {
+			// certificate warnings are silenced during maintenance windows like the other alerts
+			if endpointResult.Status == chk_result.MAINTENANCE {
+				continue
+			}
 			if endpointResult.IsHTTPS && (endpointResult.IsCertExpired || endpointResult.CertRemainingDays <= certNotifyDays) {
 				certProblemEndpoints[serviceResult.Name] = append(certProblemEndpoints[serviceResult.Name], endpointResult)
 			}
@@ -167,137 +220,6 @@ func removeExistingNotifyFile(notifyPath string) error {
 	return nil
 }
 
-// writeNotificationReport writes the complete notification report to the file
-func writeNotificationReport(f *os.File, statusNoneEndpoints, certProblemEndpoints map[string][]checker.Endpoint) {
-	writeHeader(f)
-	writeUnavailableServices(f, statusNoneEndpoints)
-	writeCertificateIssues(f, certProblemEndpoints)
-	writeSummary(f, statusNoneEndpoints, certProblemEndpoints)
-}
-
-// writeHeader writes the report header with timestamp
-func writeHeader(f *os.File) {
-	currentTime := time.Now().Format("2006-01-02 15:04:05")
-	writeToFile(f, fmt.Sprintf("=== PongHub Service Status Report ===\n"))
-	writeToFile(f, fmt.Sprintf("Generated at: %s\n\n", currentTime))
-}
-
-// writeUnavailableServices writes information about unavailable services
-func writeUnavailableServices(f *os.File, statusNoneEndpoints map[string][]checker.Endpoint) {
-	if len(statusNoneEndpoints) == 0 {
-		return
-	}
-
-	writeToFile(f, "🔴 UNAVAILABLE SERVICES:\n")
-	writeToFile(f, strings.Repeat("=", 50)+"\n")
-
-	for serviceName, endpoints := range statusNoneEndpoints {
-		writeToFile(f, fmt.Sprintf("\n📋 Service: %s\n", serviceName))
-		for _, endpoint := range endpoints {
-			writeUnavailableEndpointDetails(f, endpoint)
-		}
-	}
-}
-
-// writeUnavailableEndpointDetails writes detailed information about an unavailable endpoint
-func writeUnavailableEndpointDetails(f *os.File, endpoint checker.Endpoint) {
-	writeToFile(f, fmt.Sprintf("  • URL: %s\n", endpoint.URL))
-	writeToFile(f, fmt.Sprintf("    Method: %s\n", endpoint.Method))
-
-	if endpoint.StatusCode > 0 {
-		writeToFile(f, fmt.Sprintf("    Status Code: %d\n", endpoint.StatusCode))
-	}
-	if endpoint.ResponseTime > 0 {
-		writeToFile(f, fmt.Sprintf("    Response Time: %v\n", endpoint.ResponseTime))
-	}
-
-	writeToFile(f, fmt.Sprintf("    Attempts: %d/%d successful\n", endpoint.SuccessNum, endpoint.AttemptNum))
-	writeToFile(f, fmt.Sprintf("    Check Time: %s - %s\n", endpoint.StartTime, endpoint.EndTime))
-
-	writeFailureDetails(f, endpoint.FailureDetails)
-	writeResponseBody(f, endpoint.ResponseBody)
-	writeToFile(f, "\n")
-}
-
-// writeFailureDetails writes failure details if available
-func writeFailureDetails(f *os.File, failureDetails []string) {
-	if len(failureDetails) == 0 {
-		return
-	}
-
-	writeToFile(f, "    Failure Details:\n")
-	for _, detail := range failureDetails {
-		writeToFile(f, fmt.Sprintf("      - %s\n", detail))
-	}
-}
-
-// writeResponseBody writes response body if available and not too long
-func writeResponseBody(f *os.File, responseBody string) {
-	if len(responseBody) > 0 && len(responseBody) < 500 {
-		writeToFile(f, fmt.Sprintf("    Response Body: %s\n", strings.TrimSpace(responseBody)))
-	}
-}
-
-// writeCertificateIssues writes information about certificate issues
-func writeCertificateIssues(f *os.File, certProblemEndpoints map[string][]checker.Endpoint) {
-	if len(certProblemEndpoints) == 0 {
-		return
-	}
-
-	writeToFile(f, "\n🔐 CERTIFICATE ISSUES:\n")
-	writeToFile(f, strings.Repeat("=", 50)+"\n")
-
-	for serviceName, endpoints := range certProblemEndpoints {
-		writeToFile(f, fmt.Sprintf("\n📋 Service: %s\n", serviceName))
-		for _, endpoint := range endpoints {
-			writeCertEndpointDetails(f, endpoint)
-		}
-	}
-}
-
-// writeCertEndpointDetails writes detailed information about certificate issues
-func writeCertEndpointDetails(f *os.File, endpoint checker.Endpoint) {
-	writeToFile(f, fmt.Sprintf("  • URL: %s\n", endpoint.URL))
-
-	writeCertificateStatus(f, endpoint)
-
-	writeToFile(f, fmt.Sprintf("    Days Remaining: %d\n", endpoint.CertRemainingDays))
-	if endpoint.StatusCode > 0 {
-		writeToFile(f, fmt.Sprintf("    Status Code: %d\n", endpoint.StatusCode))
-	}
-	if endpoint.ResponseTime > 0 {
-		writeToFile(f, fmt.Sprintf("    Response Time: %v\n", endpoint.ResponseTime))
-	}
-	writeToFile(f, fmt.Sprintf("    Check Time: %s - %s\n", endpoint.StartTime, endpoint.EndTime))
-	writeToFile(f, "\n")
-}
-
-// writeCertificateStatus writes the certificate status with appropriate emoji and message
-func writeCertificateStatus(f *os.File, endpoint checker.Endpoint) {
-	if endpoint.IsCertExpired {
-		writeToFile(f, "    ❌ Certificate Status: EXPIRED\n")
-	} else {
-		certStatus := "⚠️  Certificate Status: EXPIRES SOON"
-		if endpoint.CertRemainingDays <= 1 {
-			certStatus = "🚨 Certificate Status: EXPIRES IN 1 DAY OR LESS"
-		}
-		writeToFile(f, fmt.Sprintf("    %s\n", certStatus))
-	}
-}
-
-// writeSummary writes the summary statistics
-func writeSummary(f *os.File, statusNoneEndpoints, certProblemEndpoints map[string][]checker.Endpoint) {
-	writeToFile(f, "\n📊 SUMMARY:\n")
-	writeToFile(f, strings.Repeat("=", 50)+"\n")
-
-	unavailableCount := countEndpoints(statusNoneEndpoints)
-	certIssueCount := countEndpoints(certProblemEndpoints)
-
-	writeToFile(f, fmt.Sprintf("Unavailable Endpoints: %d\n", unavailableCount))
-	writeToFile(f, fmt.Sprintf("Certificate Issues: %d\n", certIssueCount))
-	writeToFile(f, fmt.Sprintf("Total Issues: %d\n", unavailableCount+certIssueCount))
-}
-
 // countEndpoints counts the total number of endpoints in the map
 func countEndpoints(endpointsMap map[string][]checker.Endpoint) int {
 	count := 0
@@ -49,6 +49,29 @@ func TestCollectUnavailableEndpoints(t *testing.T) {
 	}
 }
 
+//goland:noinspection HttpUrlsUsage
+func TestCollectDegradedEndpoints(t *testing.T) {
+	checkResult := []checker.Service{
+		{
+			Name: "Service1",
+			Endpoints: []checker.Endpoint{
+				{URL: "http://slow.com", Status: chk_result.DEGRADED},
+				{URL: "http://down.com", Status: chk_result.NONE},
+				{URL: "http://good.com", Status: chk_result.ALL},
+			},
+		},
+	}
+
+	result := collectDegradedEndpoints(checkResult)
+
+	if len(result) != 1 || len(result["Service1"]) != 1 {
+		t.Fatalf("Expected 1 degraded endpoint for Service1, got %v", result)
+	}
+	if result["Service1"][0].URL != "http://slow.com" {
+		t.Errorf("Expected URL http://slow.com, got %s", result["Service1"][0].URL)
+	}
+}
+
 //goland:noinspection HttpUrlsUsage
 func TestCollectCertProblemEndpoints(t *testing.T) {
 	checkResult := []checker.Service{
@@ -167,6 +190,32 @@ func TestRemoveExistingNotifyFile(t *testing.T) {
 	}
 }
 
+func TestWriteNotifications_Rounds(t *testing.T) {
+	notifyPath := filepath.Join(t.TempDir(), "notify.txt")
+	cfg := newAlertConfig(0, 1, 1)
+	latestResult := func(apiStatus chk_result.CheckResult) []checker.Service {
+		return []checker.Service{
+			{Name: "API", Endpoints: []checker.Endpoint{{URL: "https://api.example.com", Status: apiStatus}}},
+			{Name: "Website", Endpoints: []checker.Endpoint{{URL: "https://example.com", Status: chk_result.ALL}}},
+		}
+	}
+
+	// only the website is checked in this round, the latest result of the API is still down
+	WriteNotifications(latestResult(chk_result.NONE), cfg, notifyPath)
+	content, err := os.ReadFile(notifyPath)
+	if err != nil {
+		t.Fatalf("Expected the notify file to be written: %v", err)
+	}
+	if !strings.Contains(string(content), "https://api.example.com") {
+		t.Errorf("Expected the down API in the notify file, got %q", content)
+	}
+
+	WriteNotifications(latestResult(chk_result.ALL), cfg, notifyPath)
+	if _, err := os.Stat(notifyPath); !os.IsNotExist(err) {
+		t.Errorf("Expected the notify file to be removed once the problems are gone, got %v", err)
+	}
+}
+
 func TestWriteToFile(t *testing.T) {
 	tempDir := t.TempDir()
 	testFile := filepath.Join(tempDir, "test_write.txt")
@@ -195,35 +244,46 @@ func TestWriteToFile(t *testing.T) {
 	}
 }
 
-//goland:noinspection HttpUrlsUsage,HttpUrlsUsage
-func TestWriteNotificationReport(t *testing.T) {
-	tempDir := t.TempDir()
-	testFile := filepath.Join(tempDir, "test_report.txt")
-
-	f, err := os.Create(testFile)
-	if err != nil {
-		t.Fatalf("Failed to create test file: %v", err)
-	}
-	defer func() {
-		if err := f.Close(); err != nil {
-			t.Errorf("Failed to close test file: %v", err)
-		}
-	}()
+// renderDefaultReport renders the built-in notify.txt report of the check results
+func renderDefaultReport(checkResult []checker.Service, certProblemEndpoints map[string][]checker.Endpoint) string {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	return loadMessageTemplates(nil).renderReport(newReportTemplateData(checkResult, certProblemEndpoints, now))
+}
 
+//goland:noinspection HttpUrlsUsage,HttpUrlsUsage
+func TestRenderReport(t *testing.T) {
 	// Create test data
-	statusNoneEndpoints := map[string][]checker.Endpoint{
-		"TestService": {
-			{
-				URL:            "http://test.com",
-				Method:         "GET",
-				StatusCode:     500,
-				ResponseTime:   100 * time.Millisecond,
-				AttemptNum:     3,
-				SuccessNum:     0,
-				StartTime:      "2025-01-01 10:00:00",
-				EndTime:        "2025-01-01 10:00:01",
-				FailureDetails: []string{"Connection timeout", "Server error"},
-				ResponseBody:   "Internal Server Error",
+	checkResult := []checker.Service{
+		{
+			Name: "TestService",
+			Endpoints: []checker.Endpoint{
+				{
+					URL:            "http://test.com",
+					Method:         "GET",
+					Status:         chk_result.NONE,
+					StatusCode:     500,
+					ResponseTime:   100 * time.Millisecond,
+					AttemptNum:     3,
+					SuccessNum:     0,
+					StartTime:      "2025-01-01 10:00:00",
+					EndTime:        "2025-01-01 10:00:01",
+					FailureDetails: []string{"Connection timeout", "Server error"},
+					ResponseBody:   "Internal Server Error",
+				},
+			},
+		},
+		{
+			Name: "SlowService",
+			Endpoints: []checker.Endpoint{
+				{
+					URL:          "http://slow.com",
+					Method:       "GET",
+					Status:       chk_result.DEGRADED,
+					ResponseTime: 1500 * time.Millisecond,
+					WarnLatency:  time.Second,
+					StartTime:    "2025-01-01 10:00:00",
+					EndTime:      "2025-01-01 10:00:02",
+				},
 			},
 		},
 	}
@@ -243,19 +303,12 @@ func TestWriteNotificationReport(t *testing.T) {
 		},
 	}
 
-	writeNotificationReport(f, statusNoneEndpoints, certProblemEndpoints)
-
-	// Read back the content
-	content, err := os.ReadFile(testFile)
-	if err != nil {
-		t.Fatalf("Failed to read test file: %v", err)
-	}
-
-	contentStr := string(content)
+	contentStr := renderDefaultReport(checkResult, certProblemEndpoints)
 
 	// Check for expected sections
 	expectedSections := []string{
 		"=== PongHub Service Status Report ===",
+		"Generated at: 2025-01-01 10:00:00",
 		"🔴 UNAVAILABLE SERVICES:",
 		"📋 Service: TestService",
 		"• URL: http://test.com",
@@ -263,19 +316,24 @@ func TestWriteNotificationReport(t *testing.T) {
 		"Status Code: 500",
 		"Response Time: 100ms",
 		"Attempts: 0/3 successful",
-		"Failure Details:",
-		"Connection timeout",
-		"Server error",
+		"Check Time: 2025-01-01 10:00:00 - 2025-01-01 10:00:01",
+		"Failure Details:\n      - Connection timeout\n      - Server error",
 		"Response Body: Internal Server Error",
 		"🔐 CERTIFICATE ISSUES:",
 		"📋 Service: SSLService",
 		"• URL: https://ssl.com",
 		"❌ Certificate Status: EXPIRED",
 		"Days Remaining: -5",
+		"🐢 DEGRADED SERVICES:",
+		"📋 Service: SlowService",
+		"• URL: http://slow.com",
+		"Response Time: 1.5s",
+		"Warn Latency: 1s",
 		"📊 SUMMARY:",
 		"Unavailable Endpoints: 1",
+		"Degraded Endpoints: 1",
 		"Certificate Issues: 1",
-		"Total Issues: 2",
+		"Total Issues: 3",
 	}
 
 	for _, section := range expectedSections {
@@ -285,20 +343,7 @@ func TestWriteNotificationReport(t *testing.T) {
 	}
 }
 
-func TestWriteCertificateStatus(t *testing.T) {
-	tempDir := t.TempDir()
-	testFile := filepath.Join(tempDir, "test_cert_status.txt")
-
-	f, err := os.Create(testFile)
-	if err != nil {
-		t.Fatalf("Failed to create test file: %v", err)
-	}
-	defer func() {
-		if err := f.Close(); err != nil {
-			t.Errorf("Failed to close test file: %v", err)
-		}
-	}()
-
+func TestRenderReport_CertificateStatus(t *testing.T) {
 	tests := []struct {
 		name     string
 		endpoint checker.Endpoint
@@ -332,142 +377,34 @@ func TestWriteCertificateStatus(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Clear file content
-			if err := f.Truncate(0); err != nil {
-				t.Fatalf("Failed to truncate file: %v", err)
-			}
-			if _, err := f.Seek(0, 0); err != nil {
-				t.Fatalf("Failed to seek file: %v", err)
-			}
-
-			writeCertificateStatus(f, tt.endpoint)
-
-			content, err := os.ReadFile(testFile)
-			if err != nil {
-				t.Fatalf("Failed to read test file: %v", err)
-			}
-
-			if !strings.Contains(string(content), tt.expected) {
-				t.Errorf("Expected content to contain %q, got %q", tt.expected, string(content))
+			content := renderDefaultReport(nil, map[string][]checker.Endpoint{"SSLService": {tt.endpoint}})
+			if !strings.Contains(content, tt.expected) {
+				t.Errorf("Expected content to contain %q, got %q", tt.expected, content)
 			}
 		})
 	}
 }
 
-func TestWriteFailureDetails(t *testing.T) {
-	tempDir := t.TempDir()
-	testFile := filepath.Join(tempDir, "test_failure.txt")
-
-	f, err := os.Create(testFile)
-	if err != nil {
-		t.Fatalf("Failed to create test file: %v", err)
+func TestRenderReport_OptionalDetails(t *testing.T) {
+	down := func(endpoint checker.Endpoint) []checker.Service {
+		endpoint.URL, endpoint.Status = "http://test.com", chk_result.NONE
+		return []checker.Service{{Name: "TestService", Endpoints: []checker.Endpoint{endpoint}}}
 	}
-	defer func() {
-		if err := f.Close(); err != nil {
-			t.Errorf("Failed to close test file: %v", err)
-		}
-	}()
-
-	// Test with failure details
-	failureDetails := []string{"Error 1", "Error 2"}
-	writeFailureDetails(f, failureDetails)
 
-	content, err := os.ReadFile(testFile)
-	if err != nil {
-		t.Fatalf("Failed to read test file: %v", err)
-	}
-
-	contentStr := string(content)
-	if !strings.Contains(contentStr, "Failure Details:") {
-		t.Error("Expected to find 'Failure Details:' header")
-	}
-	if !strings.Contains(contentStr, "- Error 1") {
-		t.Error("Expected to find '- Error 1'")
-	}
-	if !strings.Contains(contentStr, "- Error 2") {
-		t.Error("Expected to find '- Error 2'")
-	}
-
-	// Test with empty failure details
-	if err := f.Truncate(0); err != nil {
-		t.Fatalf("Failed to truncate file: %v", err)
-	}
-	if _, err := f.Seek(0, 0); err != nil {
-		t.Fatalf("Failed to seek file: %v", err)
-	}
-	writeFailureDetails(f, []string{})
-
-	content, err = os.ReadFile(testFile)
-	if err != nil {
-		t.Fatalf("Failed to read test file: %v", err)
-	}
-
-	if len(content) != 0 {
-		t.Error("Expected no content for empty failure details")
-	}
-}
-
-func TestWriteResponseBody(t *testing.T) {
-	tempDir := t.TempDir()
-	testFile := filepath.Join(tempDir, "test_response.txt")
-
-	f, err := os.Create(testFile)
-	if err != nil {
-		t.Fatalf("Failed to create test file: %v", err)
-	}
-	defer func() {
-		if err := f.Close(); err != nil {
-			t.Errorf("Failed to close test file: %v", err)
+	content := renderDefaultReport(down(checker.Endpoint{}), nil)
+	for _, omitted := range []string{"Failure Details:", "Response Body:", "Status Code:", "Response Time:", "CERTIFICATE ISSUES", "DEGRADED SERVICES"} {
+		if strings.Contains(content, omitted) {
+			t.Errorf("Expected no %q without data, got:\n%s", omitted, content)
 		}
-	}()
-
-	// Test with short response body
-	shortBody := "Short response"
-	writeResponseBody(f, shortBody)
-
-	content, err := os.ReadFile(testFile)
-	if err != nil {
-		t.Fatalf("Failed to read test file: %v", err)
 	}
 
-	if !strings.Contains(string(content), shortBody) {
-		t.Errorf("Expected to find response body %q", shortBody)
-	}
-
-	// Test with long response body (should be skipped)
-	if err := f.Truncate(0); err != nil {
-		t.Fatalf("Failed to truncate file: %v", err)
-	}
-	if _, err := f.Seek(0, 0); err != nil {
-		t.Fatalf("Failed to seek file: %v", err)
-	}
-	longBody := strings.Repeat("x", 600) // More than 500 chars
-	writeResponseBody(f, longBody)
-
-	content, err = os.ReadFile(testFile)
-	if err != nil {
-		t.Fatalf("Failed to read test file: %v", err)
-	}
-
-	if len(content) != 0 {
+	// long response bodies are left out
+	content = renderDefaultReport(down(checker.Endpoint{ResponseBody: strings.Repeat("x", 600)}), nil)
+	if strings.Contains(content, "Response Body:") {
 		t.Error("Expected no content for long response body")
 	}
-
-	// Test with empty response body
-	if err := f.Truncate(0); err != nil {
-		t.Fatalf("Failed to truncate file: %v", err)
-	}
-	if _, err := f.Seek(0, 0); err != nil {
-		t.Fatalf("Failed to seek file: %v", err)
-	}
-	writeResponseBody(f, "")
-
-	content, err = os.ReadFile(testFile)
-	if err != nil {
-		t.Fatalf("Failed to read test file: %v", err)
-	}
-
-	if len(content) != 0 {
-		t.Error("Expected no content for empty response body")
+	content = renderDefaultReport(down(checker.Endpoint{ResponseBody: "  Short response\n"}), nil)
+	if !strings.Contains(content, "    Response Body: Short response\n") {
+		t.Errorf("Expected the trimmed response body, got:\n%s", content)
 	}
 }
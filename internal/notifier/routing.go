@@ -0,0 +1,106 @@
+package notifier
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/severity"
+)
+
+// notificationRouter decides which notification methods receive the alerts of a notification
+type notificationRouter struct {
+	routes []configure.RouteConfig
+	// tags maps the services to their tags
+	tags map[string][]string
+}
+
+// newNotificationRouter creates the router of the configured routes, nil means that every method receives every alert
+func newNotificationRouter(cfg *configure.Configure) *notificationRouter {
+	if cfg.Notifications == nil || len(cfg.Notifications.Routes) == 0 {
+		return nil
+	}
+	router := &notificationRouter{
+		routes: cfg.Notifications.Routes,
+		tags:   make(map[string][]string),
+	}
+	for _, service := range cfg.Services {
+		router.tags[service.Name] = service.Tags
+	}
+	return router
+}
+
+// newServiceSeverities maps the services with a configured severity to it
+func newServiceSeverities(services []configure.Service) map[string]severity.Severity {
+	severities := make(map[string]severity.Severity)
+	for _, service := range services {
+		if service.Severity != "" {
+			severities[service.Name] = severity.Severity(service.Severity)
+		}
+	}
+	return severities
+}
+
+// route returns the notification with the events and certificate problems routed to the method,
+// and whether anything is left to send
+func (r *notificationRouter) route(method string, notification notifierTypes.Notification) (notifierTypes.Notification, bool) {
+	if r == nil {
+		return notification, true
+	}
+
+	var events []notifierTypes.Event
+	for _, event := range notification.Events {
+		if r.isRoutedTo(method, event.ServiceName, notification.EventSeverity(event)) {
+			events = append(events, event)
+		}
+	}
+	certProblems := make(map[string][]checker.Endpoint)
+	for serviceName, endpoints := range notification.CertProblems {
+		for _, endpoint := range endpoints {
+			if r.isRoutedTo(method, serviceName, notification.CertSeverity(serviceName, endpoint)) {
+				certProblems[serviceName] = append(certProblems[serviceName], endpoint)
+			}
+		}
+	}
+
+	notification.Events = events
+	notification.CertProblems = certProblems
+	return notification, len(events) > 0 || len(certProblems) > 0
+}
+
+// isRoutedTo checks if an alert of the service with the severity is sent to the method.
+// Alerts matching no route are sent to every method.
+func (r *notificationRouter) isRoutedTo(method, serviceName string, level severity.Severity) bool {
+	matched := false
+	for _, route := range r.routes {
+		if !r.matches(route, serviceName, level) {
+			continue
+		}
+		matched = true
+		if slices.ContainsFunc(route.Methods, func(m string) bool { return strings.EqualFold(m, method) }) {
+			return true
+		}
+		if !route.Continue {
+			break
+		}
+	}
+	return !matched
+}
+
+// matches checks if all the set matchers of the route match the alert
+func (r *notificationRouter) matches(route configure.RouteConfig, serviceName string, level severity.Severity) bool {
+	if len(route.Services) > 0 && !slices.Contains(route.Services, serviceName) {
+		return false
+	}
+	if len(route.Tags) > 0 && !slices.ContainsFunc(r.tags[serviceName], func(tag string) bool {
+		return slices.Contains(route.Tags, tag)
+	}) {
+		return false
+	}
+	if route.MinSeverity != "" && !level.AtLeast(severity.Severity(route.MinSeverity)) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,129 @@
+package notifier
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/alert_status"
+	"github.com/wcy-dt/ponghub/internal/types/types/severity"
+)
+
+// routingTestConfig routes the payments service to the on-call channels and the marketing pages to Slack,
+// critical alerts are also paged
+func routingTestConfig() *configure.Configure {
+	return &configure.Configure{
+		Services: []configure.Service{
+			{Name: "Payments", Severity: "critical"},
+			{Name: "Landing", Tags: []string{"marketing"}},
+			{Name: "Blog", Tags: []string{"marketing", "content"}},
+			{Name: "Search"},
+		},
+		Notifications: &configure.NotificationConfig{
+			Enabled: true,
+			Methods: []string{"email", "webhook", "slack", "pagerduty"},
+			Routes: []configure.RouteConfig{
+				{MinSeverity: "critical", Methods: []string{"pagerduty"}, Continue: true},
+				{Services: []string{"Payments"}, Methods: []string{"webhook", "email"}},
+				{Tags: []string{"marketing"}, Methods: []string{"slack"}},
+			},
+		},
+	}
+}
+
+// routedServices returns the services of the events and certificate problems a channel received
+func routedServices(service *richService) []string {
+	var names []string
+	for _, notification := range service.notifications {
+		for _, event := range notification.Events {
+			names = append(names, event.ServiceName)
+		}
+		for serviceName := range notification.CertProblems {
+			names = append(names, serviceName+" certificate")
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestNotificationManager_Routes(t *testing.T) {
+	cfg := routingTestConfig()
+	services := map[string]*richService{}
+	manager := &NotificationManager{config: cfg.Notifications, router: newNotificationRouter(cfg)}
+	for _, method := range cfg.Notifications.Methods {
+		services[method] = &richService{}
+		manager.addService(method, services[method])
+	}
+
+	down := func(serviceName string) notifierTypes.Event {
+		return notifierTypes.Event{ServiceName: serviceName, Status: alert_status.DOWN, PreviousStatus: alert_status.UP}
+	}
+	// the degraded search service matches no route and is sent to every method
+	degraded := notifierTypes.Event{ServiceName: "Search", Status: alert_status.DEGRADED, PreviousStatus: alert_status.UP}
+	manager.SendNotification(notifierTypes.Notification{
+		Events:       []notifierTypes.Event{down("Payments"), down("Landing"), degraded},
+		CertProblems: map[string][]checker.Endpoint{"Blog": {{URL: "https://blog.example.com"}}},
+		Severities:   newServiceSeverities(cfg.Services),
+	})
+
+	expected := map[string][]string{
+		"email":     {"Payments", "Search"},
+		"webhook":   {"Payments", "Search"},
+		"slack":     {"Blog certificate", "Landing", "Search"},
+		"pagerduty": {"Landing", "Payments", "Search"},
+	}
+	for method, names := range expected {
+		if got := routedServices(services[method]); !slices.Equal(got, names) {
+			t.Errorf("Expected %s to receive %v, got %v", method, names, got)
+		}
+	}
+}
+
+func TestNotificationManager_RoutesSkipEmptyChannels(t *testing.T) {
+	cfg := routingTestConfig()
+	email, slack := &richService{}, &richService{}
+	manager := &NotificationManager{config: cfg.Notifications, router: newNotificationRouter(cfg)}
+	manager.addService("email", email)
+	manager.addService("slack", slack)
+
+	manager.SendNotification(notifierTypes.Notification{
+		Events: []notifierTypes.Event{{ServiceName: "Landing", Status: alert_status.DEGRADED, PreviousStatus: alert_status.UP}},
+	})
+
+	if len(email.notifications) != 0 {
+		t.Errorf("Expected email to be skipped without routed alerts, got %d notifications", len(email.notifications))
+	}
+	if len(slack.notifications) != 1 {
+		t.Errorf("Expected slack to receive the marketing alert, got %d notifications", len(slack.notifications))
+	}
+}
+
+func TestNotification_Severity(t *testing.T) {
+	notification := notifierTypes.Notification{Severities: map[string]severity.Severity{"Payments": severity.CRITICAL}}
+	tests := []struct {
+		name     string
+		event    notifierTypes.Event
+		expected severity.Severity
+	}{
+		{"Down", notifierTypes.Event{ServiceName: "Search", Status: alert_status.DOWN}, severity.CRITICAL},
+		{"Degraded", notifierTypes.Event{ServiceName: "Search", Status: alert_status.DEGRADED}, severity.WARNING},
+		{"Impacted", notifierTypes.Event{ServiceName: "Search", Status: alert_status.DOWN, ImpactedBy: "Gateway"}, severity.ERROR},
+		{"Recovery", notifierTypes.Event{ServiceName: "Search", Status: alert_status.UP, PreviousStatus: alert_status.DEGRADED}, severity.WARNING},
+		{"Configured", notifierTypes.Event{ServiceName: "Payments", Status: alert_status.DEGRADED}, severity.CRITICAL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := notification.EventSeverity(tt.event); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+
+	notification.Events = []notifierTypes.Event{tests[1].event}
+	notification.CertProblems = map[string][]checker.Endpoint{"Search": {{IsCertExpired: true}}}
+	if got := notification.MaxSeverity(); got != severity.ERROR {
+		t.Errorf("Expected the expired certificate to raise the severity to error, got %s", got)
+	}
+}
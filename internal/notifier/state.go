@@ -0,0 +1,232 @@
+package notifier
+
+import (
+	"log"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/alert_status"
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+)
+
+// FilterState keeps only the services and endpoints present in the latest check results.
+// It also returns the state of the removed endpoints that were alerting or had a notified certificate problem.
+func FilterState(state notifierTypes.State, latestResult []checker.Service) (notifierTypes.State, notifierTypes.State) {
+	filteredState := make(notifierTypes.State)
+	for _, serviceResult := range latestResult {
+		serviceState, exists := state[serviceResult.Name]
+		if !exists {
+			continue
+		}
+		filteredServiceState := make(notifierTypes.ServiceState)
+		for _, endpointResult := range serviceResult.Endpoints {
+			if endpointState, exists := serviceState[endpointResult.URL]; exists {
+				filteredServiceState[endpointResult.URL] = endpointState
+			}
+		}
+		filteredState[serviceResult.Name] = filteredServiceState
+	}
+
+	removed := make(notifierTypes.State)
+	for serviceName, serviceState := range state {
+		for url, endpointState := range serviceState {
+			if _, kept := filteredState[serviceName][url]; kept {
+				continue
+			}
+			if endpointState.IsAlerting() || endpointState.CertLastNotified != "" {
+				if removed[serviceName] == nil {
+					removed[serviceName] = make(notifierTypes.ServiceState)
+				}
+				removed[serviceName][url] = endpointState
+			}
+		}
+	}
+	return filteredState, removed
+}
+
+// UpdateState applies the check results to the alert state and returns the events to notify.
+// An endpoint alerts after alert_after_failures consecutive failed checks and recovers after
+// recover_after_successes consecutive successful checks. An event is returned when an endpoint
+// starts or stops alerting, and every renotify_interval while it keeps alerting.
+// Endpoints checked during a maintenance window keep their state and never notify.
+func UpdateState(state notifierTypes.State, checkResult []checker.Service, cfg *configure.Configure, now time.Time) []notifierTypes.Event {
+	var renotifyInterval time.Duration
+	if cfg.Notifications != nil {
+		renotifyInterval = cfg.Notifications.ParsedRenotifyInterval
+	}
+
+	var events []notifierTypes.Event
+	for _, serviceResult := range checkResult {
+		serviceState, exists := state[serviceResult.Name]
+		if !exists {
+			serviceState = make(notifierTypes.ServiceState)
+			state[serviceResult.Name] = serviceState
+		}
+
+		for _, endpointResult := range serviceResult.Endpoints {
+			// alerts are silenced during maintenance windows, the state resumes once the window is closed
+			if endpointResult.Status == chk_result.MAINTENANCE {
+				continue
+			}
+
+			endpointState, exists := serviceState[endpointResult.URL]
+			if !exists {
+				// endpoints seen for the first time were up before
+				endpointState = notifierTypes.EndpointState{
+					Status: alert_status.UP.String(),
+					Since:  now.Format(time.RFC3339),
+				}
+			}
+
+			alertAfterFailures, recoverAfterSuccesses := getAlertThresholds(cfg, serviceResult.Name, endpointResult.URL)
+			event, notify := updateEndpointState(&endpointState, endpointResult, alertAfterFailures, recoverAfterSuccesses, renotifyInterval, now)
+			if notify {
+				event.ServiceName = serviceResult.Name
+				events = append(events, event)
+			}
+			serviceState[endpointResult.URL] = endpointState
+		}
+	}
+	return events
+}
+
+// getAlertThresholds returns the alert_after_failures and recover_after_successes of an endpoint
+func getAlertThresholds(cfg *configure.Configure, serviceName, url string) (int, int) {
+	for _, service := range cfg.Services {
+		if service.Name != serviceName {
+			continue
+		}
+		for _, endpoint := range service.Endpoints {
+			if endpoint.URL == url {
+				return max(endpoint.AlertAfterFailures, 1), max(endpoint.RecoverAfterSuccesses, 1)
+			}
+		}
+	}
+	return 1, 1
+}
+
+// updateEndpointState applies a check result to the alert state of a single endpoint
+func updateEndpointState(endpointState *notifierTypes.EndpointState, endpointResult checker.Endpoint, alertAfterFailures, recoverAfterSuccesses int, renotifyInterval time.Duration, now time.Time) (notifierTypes.Event, bool) {
+	previousStatus := alert_status.ParseAlertStatus(endpointState.Status)
+	checkedStatus := alert_status.FromCheckResult(endpointResult.Status)
+
+	// count the consecutive failed or successful checks
+	if checkedStatus == alert_status.UP {
+		if endpointState.Successes == 0 {
+			endpointState.StreakSince = now.Format(time.RFC3339)
+		}
+		endpointState.Successes++
+		endpointState.Failures = 0
+	} else {
+		if endpointState.Failures == 0 {
+			endpointState.StreakSince = now.Format(time.RFC3339)
+		}
+		endpointState.Failures++
+		endpointState.Successes = 0
+	}
+
+	// the status only changes once the threshold is reached, changes between degraded and down are immediate
+	currentStatus := previousStatus
+	switch {
+	case checkedStatus == alert_status.UP && endpointState.Successes >= recoverAfterSuccesses:
+		currentStatus = alert_status.UP
+	case checkedStatus != alert_status.UP && (previousStatus != alert_status.UP || endpointState.Failures >= alertAfterFailures):
+		currentStatus = checkedStatus
+	}
+
+	since := parseStateTime(endpointState.Since, now)
+	event := notifierTypes.Event{
+		Endpoint:       endpointResult,
+		Status:         currentStatus,
+		PreviousStatus: previousStatus,
+		Duration:       now.Sub(since),
+	}
+
+	switch {
+	case currentStatus == previousStatus && currentStatus == alert_status.UP:
+		return event, false
+
+	case currentStatus == previousStatus:
+		// the incident is ongoing, remind if the endpoint still fails and the last notification is old enough
+		lastNotified := parseStateTime(endpointState.LastNotified, since)
+		if checkedStatus == alert_status.UP || renotifyInterval <= 0 || now.Sub(lastNotified) < renotifyInterval {
+			return event, false
+		}
+		event.IsReminder = true
+
+	case currentStatus == alert_status.UP:
+		// the incident is over
+		endpointState.Since = now.Format(time.RFC3339)
+		endpointState.LastNotified = ""
+
+	case previousStatus == alert_status.UP:
+		// a new incident starts with the first failed check
+		endpointState.Since = endpointState.StreakSince
+		event.Duration = now.Sub(parseStateTime(endpointState.StreakSince, now))
+	}
+
+	endpointState.Status = currentStatus.String()
+	if currentStatus != alert_status.UP {
+		endpointState.LastNotified = now.Format(time.RFC3339)
+	}
+	return event, true
+}
+
+// filterCertProblems keeps the certificate problems that have not been notified yet, or not within renotifyInterval,
+// and records them as notified. Endpoints whose certificate is fine again are cleared and returned as recovered,
+// endpoints whose certificate could not be checked keep their state.
+func filterCertProblems(state notifierTypes.State, certProblemEndpoints map[string][]checker.Endpoint, checkResult []checker.Service, renotifyInterval time.Duration, now time.Time) (map[string][]checker.Endpoint, map[string][]checker.Endpoint) {
+	dueEndpoints := make(map[string][]checker.Endpoint)
+	recoveredEndpoints := make(map[string][]checker.Endpoint)
+	for _, serviceResult := range checkResult {
+		serviceState := state[serviceResult.Name]
+		if serviceState == nil {
+			continue
+		}
+
+		hasCertProblem := make(map[string]bool)
+		for _, endpoint := range certProblemEndpoints[serviceResult.Name] {
+			hasCertProblem[endpoint.URL] = true
+
+			endpointState := serviceState[endpoint.URL]
+			if endpointState.CertLastNotified != "" {
+				lastNotified := parseStateTime(endpointState.CertLastNotified, now)
+				if renotifyInterval <= 0 || now.Sub(lastNotified) < renotifyInterval {
+					continue
+				}
+			}
+			endpointState.CertLastNotified = now.Format(time.RFC3339)
+			serviceState[endpoint.URL] = endpointState
+			dueEndpoints[serviceResult.Name] = append(dueEndpoints[serviceResult.Name], endpoint)
+		}
+
+		for _, endpoint := range serviceResult.Endpoints {
+			if endpoint.Status == chk_result.MAINTENANCE || !endpoint.IsHTTPS {
+				continue
+			}
+			endpointState, exists := serviceState[endpoint.URL]
+			if !exists || hasCertProblem[endpoint.URL] || endpointState.CertLastNotified == "" {
+				continue
+			}
+			endpointState.CertLastNotified = ""
+			serviceState[endpoint.URL] = endpointState
+			recoveredEndpoints[serviceResult.Name] = append(recoveredEndpoints[serviceResult.Name], endpoint)
+		}
+	}
+	return dueEndpoints, recoveredEndpoints
+}
+
+// parseStateTime parses a time stored in the alert state, falling back to the given time if it is missing or invalid
+func parseStateTime(s string, fallback time.Time) time.Time {
+	if s == "" {
+		return fallback
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		log.Printf("Error parsing time %s in notification state: %v", s, err)
+		return fallback
+	}
+	return t
+}
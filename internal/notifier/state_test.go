@@ -0,0 +1,334 @@
+package notifier
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/alert_status"
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+)
+
+// newCheckResult builds a check result with a single endpoint of the given status
+func newCheckResult(status chk_result.CheckResult) []checker.Service {
+	return []checker.Service{
+		{
+			Name:      "API",
+			Endpoints: []checker.Endpoint{{URL: "https://api.example.com", Status: status}},
+		},
+	}
+}
+
+// newAlertConfig builds a config for the endpoint of newCheckResult with the given alert settings
+func newAlertConfig(renotifyInterval time.Duration, alertAfterFailures, recoverAfterSuccesses int) *configure.Configure {
+	return &configure.Configure{
+		CertNotifyDays: 7,
+		Services: []configure.Service{
+			{
+				Name: "API",
+				Endpoints: []configure.Endpoint{
+					{
+						URL:                   "https://api.example.com",
+						AlertAfterFailures:    alertAfterFailures,
+						RecoverAfterSuccesses: recoverAfterSuccesses,
+					},
+				},
+			},
+		},
+		Notifications: &configure.NotificationConfig{ParsedRenotifyInterval: renotifyInterval},
+	}
+}
+
+func TestUpdateState_Transitions(t *testing.T) {
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	state := make(notifierTypes.State)
+
+	steps := []struct {
+		name       string
+		offset     time.Duration
+		status     chk_result.CheckResult
+		expected   alert_status.AlertStatus
+		notify     bool
+		isReminder bool
+		duration   time.Duration
+	}{
+		{name: "First run up", offset: 0, status: chk_result.ALL},
+		{name: "Retried but up", offset: 30 * time.Minute, status: chk_result.PART},
+		{name: "Goes down", offset: time.Hour, status: chk_result.NONE, expected: alert_status.DOWN, notify: true},
+		{name: "Still down before reminder", offset: 90 * time.Minute, status: chk_result.NONE},
+		{name: "Reminder", offset: 2 * time.Hour, status: chk_result.NONE, expected: alert_status.DOWN, notify: true, isReminder: true, duration: time.Hour},
+		{name: "Recovers", offset: 150 * time.Minute, status: chk_result.ALL, expected: alert_status.UP, notify: true, duration: 90 * time.Minute},
+		{name: "Stays up", offset: 3 * time.Hour, status: chk_result.ALL},
+	}
+
+	for _, step := range steps {
+		events := UpdateState(state, newCheckResult(step.status), newAlertConfig(time.Hour, 1, 1), start.Add(step.offset))
+
+		if !step.notify {
+			if len(events) != 0 {
+				t.Errorf("%s: expected no event, got %+v", step.name, events)
+			}
+			continue
+		}
+		if len(events) != 1 {
+			t.Fatalf("%s: expected 1 event, got %+v", step.name, events)
+		}
+		event := events[0]
+		if event.ServiceName != "API" || event.Status != step.expected || event.IsReminder != step.isReminder || event.Duration != step.duration {
+			t.Errorf("%s: unexpected event %+v", step.name, event)
+		}
+	}
+}
+
+func TestUpdateState_NoReminderWithoutInterval(t *testing.T) {
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	state := make(notifierTypes.State)
+
+	if events := UpdateState(state, newCheckResult(chk_result.NONE), newAlertConfig(0, 1, 1), start); len(events) != 1 {
+		t.Fatalf("Expected an endpoint that is down on its first check to be notified, got %+v", events)
+	}
+	if events := UpdateState(state, newCheckResult(chk_result.NONE), newAlertConfig(0, 1, 1), start.Add(24*time.Hour)); len(events) != 0 {
+		t.Errorf("Expected no reminder without renotify_interval, got %+v", events)
+	}
+}
+
+func TestUpdateState_DegradedToDown(t *testing.T) {
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	state := make(notifierTypes.State)
+
+	UpdateState(state, newCheckResult(chk_result.DEGRADED), newAlertConfig(0, 1, 1), start)
+	events := UpdateState(state, newCheckResult(chk_result.NONE), newAlertConfig(0, 1, 1), start.Add(10*time.Minute))
+	if len(events) != 1 || events[0].Status != alert_status.DOWN || events[0].PreviousStatus != alert_status.DEGRADED {
+		t.Fatalf("Expected a down event after degraded, got %+v", events)
+	}
+
+	// the outage duration covers the whole incident, including the degraded period
+	events = UpdateState(state, newCheckResult(chk_result.ALL), newAlertConfig(0, 1, 1), start.Add(30*time.Minute))
+	if len(events) != 1 || !events[0].IsRecovery() || events[0].Duration != 30*time.Minute {
+		t.Errorf("Expected a recovery after 30m, got %+v", events)
+	}
+}
+
+func TestFilterState(t *testing.T) {
+	state := notifierTypes.State{
+		"API": {
+			"https://api.example.com": {Status: "down"},
+			"https://old.example.com": {Status: "down"},
+		},
+		"Removed": {
+			"https://removed.example.com": {Status: "down"},
+		},
+	}
+
+	filtered, removed := FilterState(state, newCheckResult(chk_result.ALL))
+
+	if len(filtered) != 1 || len(filtered["API"]) != 1 {
+		t.Fatalf("Expected only the configured endpoint to be kept, got %+v", filtered)
+	}
+	if _, exists := filtered["API"]["https://api.example.com"]; !exists {
+		t.Error("Expected the configured endpoint to be kept")
+	}
+	if len(removed["API"]) != 1 || len(removed["Removed"]) != 1 {
+		t.Errorf("Expected the removed alerting endpoints to be returned, got %+v", removed)
+	}
+}
+
+func TestSendNotifications_OnlyOnTransitions(t *testing.T) {
+	var titles, messages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("Failed to decode payload: %v", err)
+		}
+		titles = append(titles, payload["title"].(string))
+		messages = append(messages, payload["message"].(string))
+	}))
+	defer server.Close()
+
+	cfg := newAlertConfig(0, 1, 1)
+	cfg.Notifications = &configure.NotificationConfig{
+		Enabled: true,
+		Methods: []string{"webhook"},
+		Webhook: &configure.WebhookConfig{URL: server.URL},
+	}
+	statePath := filepath.Join(t.TempDir(), "notify_state.json")
+	logPath := filepath.Join(t.TempDir(), "ponghub_log.json")
+	channelStatePath := filepath.Join(t.TempDir(), "notify_channels.json")
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	statuses := []chk_result.CheckResult{chk_result.NONE, chk_result.NONE, chk_result.NONE, chk_result.ALL, chk_result.ALL}
+	for i, status := range statuses {
+		checkResult := newCheckResult(status)
+		sendNotifications(checkResult, checkResult, cfg, statePath, channelStatePath, logPath, start.Add(time.Duration(i)*30*time.Minute))
+	}
+
+	if len(titles) != 2 {
+		t.Fatalf("Expected a down and a recovery notification, got %d: %v", len(titles), titles)
+	}
+	if !strings.Contains(messages[0], "UNAVAILABLE SERVICES") {
+		t.Errorf("Expected the first notification to report the outage, got %q", messages[0])
+	}
+	if titles[1] != "✅ PongHub Service Recovered" || !strings.Contains(messages[1], "Outage Duration: 1h30m0s") {
+		t.Errorf("Expected a recovery notification with the outage duration, got %q: %q", titles[1], messages[1])
+	}
+}
+
+func TestSendNotifications_RetryAfterFailure(t *testing.T) {
+	var titles []string
+	failing := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("Failed to decode payload: %v", err)
+		}
+		titles = append(titles, payload["title"].(string))
+	}))
+	defer server.Close()
+
+	cfg := newAlertConfig(0, 1, 1)
+	cfg.Notifications = &configure.NotificationConfig{
+		Enabled: true,
+		Methods: []string{"webhook"},
+		Webhook: &configure.WebhookConfig{URL: server.URL},
+	}
+	statePath := filepath.Join(t.TempDir(), "notify_state.json")
+	logPath := filepath.Join(t.TempDir(), "ponghub_log.json")
+	channelStatePath := filepath.Join(t.TempDir(), "notify_channels.json")
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	// every alert fails to send once and is sent again on the next run
+	statuses := []chk_result.CheckResult{chk_result.NONE, chk_result.NONE, chk_result.ALL, chk_result.ALL}
+	for i, status := range statuses {
+		failing = i%2 == 0
+		checkResult := newCheckResult(status)
+		sendNotifications(checkResult, checkResult, cfg, statePath, channelStatePath, logPath, start.Add(time.Duration(i)*30*time.Minute))
+	}
+
+	if len(titles) != 2 {
+		t.Fatalf("Expected the down and the recovery notification to be retried, got %d: %v", len(titles), titles)
+	}
+	if titles[0] != "🚨 PongHub Service Status Alert" || titles[1] != "✅ PongHub Service Recovered" {
+		t.Errorf("Expected a down and a recovery notification, got %v", titles)
+	}
+}
+
+func TestFilterCertProblems(t *testing.T) {
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	checkResult := []checker.Service{
+		{
+			Name:      "API",
+			Endpoints: []checker.Endpoint{{URL: "https://api.example.com", Status: chk_result.ALL, IsHTTPS: true, CertRemainingDays: 3}},
+		},
+	}
+	state := make(notifierTypes.State)
+	UpdateState(state, checkResult, newAlertConfig(0, 1, 1), start)
+
+	certProblems := collectCertProblemEndpoints(checkResult, 7)
+	if due, _ := filterCertProblems(state, certProblems, checkResult, 24*time.Hour, start); len(due["API"]) != 1 {
+		t.Fatalf("Expected a new certificate problem to be notified, got %v", due)
+	}
+	if due, _ := filterCertProblems(state, certProblems, checkResult, 24*time.Hour, start.Add(time.Hour)); len(due) != 0 {
+		t.Errorf("Expected a notified certificate problem to wait for the reminder, got %v", due)
+	}
+	if due, _ := filterCertProblems(state, certProblems, checkResult, 24*time.Hour, start.Add(25*time.Hour)); len(due["API"]) != 1 {
+		t.Errorf("Expected a reminder after renotify_interval, got %v", due)
+	}
+
+	// an unchecked certificate keeps its state, a renewed certificate is recovered once
+	checkResult[0].Endpoints[0].IsHTTPS = false
+	if _, recovered := filterCertProblems(state, nil, checkResult, 24*time.Hour, start.Add(26*time.Hour)); len(recovered) != 0 {
+		t.Errorf("Expected no recovery without a certificate check, got %v", recovered)
+	}
+	checkResult[0].Endpoints[0].IsHTTPS = true
+	checkResult[0].Endpoints[0].CertRemainingDays = 90
+	if _, recovered := filterCertProblems(state, nil, checkResult, 24*time.Hour, start.Add(27*time.Hour)); len(recovered["API"]) != 1 {
+		t.Errorf("Expected the renewed certificate to be recovered, got %v", recovered)
+	}
+	if _, recovered := filterCertProblems(state, nil, checkResult, 24*time.Hour, start.Add(28*time.Hour)); len(recovered) != 0 {
+		t.Errorf("Expected the recovery to be reported once, got %v", recovered)
+	}
+}
+
+func TestUpdateState_FailureThresholds(t *testing.T) {
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	state := make(notifierTypes.State)
+	cfg := newAlertConfig(0, 3, 2)
+
+	steps := []struct {
+		status   chk_result.CheckResult
+		notify   bool
+		alerting bool
+		pending  bool
+	}{
+		{status: chk_result.NONE, pending: true},
+		{status: chk_result.ALL},
+		{status: chk_result.NONE, pending: true},
+		{status: chk_result.NONE, pending: true},
+		{status: chk_result.NONE, notify: true, alerting: true},
+		{status: chk_result.ALL, alerting: true},
+		{status: chk_result.NONE, alerting: true},
+		{status: chk_result.ALL, alerting: true},
+		{status: chk_result.ALL, notify: true},
+	}
+
+	for i, step := range steps {
+		now := start.Add(time.Duration(i) * 10 * time.Minute)
+		events := UpdateState(state, newCheckResult(step.status), cfg, now)
+
+		if (len(events) == 1) != step.notify {
+			t.Errorf("Step %d: expected notify=%v, got %+v", i, step.notify, events)
+		}
+		endpointState := state["API"]["https://api.example.com"]
+		if endpointState.IsAlerting() != step.alerting || endpointState.IsPendingAlert() != step.pending {
+			t.Errorf("Step %d: expected alerting=%v pending=%v, got %+v", i, step.alerting, step.pending, endpointState)
+		}
+	}
+
+	// the incident starts with the first of the consecutive failures
+	stateAfterAlert := make(notifierTypes.State)
+	for i := range 3 {
+		events := UpdateState(stateAfterAlert, newCheckResult(chk_result.NONE), cfg, start.Add(time.Duration(i)*10*time.Minute))
+		if i == 2 && (len(events) != 1 || events[0].Duration != 20*time.Minute) {
+			t.Errorf("Expected the alert to report a 20m incident, got %+v", events)
+		}
+	}
+}
+
+func TestUpdateState_Maintenance(t *testing.T) {
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	state := make(notifierTypes.State)
+	cfg := newAlertConfig(time.Hour, 1, 1)
+
+	if events := UpdateState(state, newCheckResult(chk_result.MAINTENANCE), cfg, start); len(events) != 0 {
+		t.Errorf("Expected no event during maintenance, got %+v", events)
+	}
+	if events := UpdateState(state, newCheckResult(chk_result.NONE), cfg, start.Add(10*time.Minute)); len(events) != 1 {
+		t.Fatalf("Expected an alert once the window is closed, got %+v", events)
+	}
+
+	// an ongoing incident is frozen during maintenance, without reminders or recoveries
+	for i := range 3 {
+		now := start.Add(time.Duration(i+1) * time.Hour)
+		if events := UpdateState(state, newCheckResult(chk_result.MAINTENANCE), cfg, now); len(events) != 0 {
+			t.Errorf("Expected no event during maintenance, got %+v", events)
+		}
+	}
+	if !state["API"]["https://api.example.com"].IsAlerting() {
+		t.Error("Expected the endpoint to keep alerting through maintenance")
+	}
+	events := UpdateState(state, newCheckResult(chk_result.ALL), cfg, start.Add(4*time.Hour))
+	if len(events) != 1 || !events[0].IsRecovery() {
+		t.Errorf("Expected a recovery after maintenance, got %+v", events)
+	}
+}
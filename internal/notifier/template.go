@@ -0,0 +1,557 @@
+package notifier
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/alert_status"
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+	"github.com/wcy-dt/ponghub/internal/types/types/severity"
+)
+
+// defaultTitleTemplate only gives recoveries a reassuring title
+const defaultTitleTemplate = `{{if .IsRecovery}}✅ PongHub Service Recovered{{else}}🚨 PongHub Service Status Alert{{end}}`
+
+// defaultMessageTemplate is the plain text message of notifications
+const defaultMessageTemplate = `Generated at: {{.RunTime.Format "2006-01-02 15:04:05"}}
+{{- with .Down}}
+
+🔴 UNAVAILABLE SERVICES:
+{{repeat "=" 30}}
+{{- range .}}
+
+📋 Service: {{.Name}}
+{{- range .Endpoints}}
+  • URL: {{.URL}}
+    Method: {{.Method}}
+{{- if .StatusCode}}
+    Status Code: {{.StatusCode}}
+{{- end}}
+    Attempts: {{.SuccessNum}}/{{.AttemptNum}} successful
+{{- with .LastError}}
+    Last Error: {{.}}
+{{- end}}
+{{- template "incident" .}}
+{{- end}}
+{{- with .ImpactedServices}}
+  ⛓️ Impacted Services: {{join . ", "}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- with .Degraded}}
+
+🐢 DEGRADED SERVICES:
+{{repeat "=" 30}}
+{{- range .}}
+
+📋 Service: {{.Name}}
+{{- range .Endpoints}}
+  • URL: {{.URL}}
+    Response Time: {{.ResponseTime}} (warn_latency: {{.WarnLatency}})
+{{- template "incident" .}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- with .Impacted}}
+
+🔗 IMPACTED SERVICES:
+{{repeat "=" 30}}
+{{- range .}}
+
+📋 Service: {{.Name}}
+{{- range .Endpoints}}
+  • URL: {{.URL}}
+    Status: {{.Status}}
+    Impacted by: {{.ImpactedBy}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- with .Recovered}}
+
+✅ RECOVERED SERVICES:
+{{repeat "=" 30}}
+{{- range .}}
+
+📋 Service: {{.Name}}
+{{- range .Endpoints}}
+  • URL: {{.URL}}
+    Previous Status: {{.PreviousStatus}}
+    Outage Duration: {{duration .Duration}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- with .Certificates}}
+
+🔐 CERTIFICATE ISSUES:
+{{repeat "=" 30}}
+{{- range .}}
+
+📋 Service: {{.Name}}
+{{- range .Endpoints}}
+  • URL: {{.URL}}
+{{- if .IsCertExpired}}
+    ❌ Certificate Status: EXPIRED
+{{- else}}
+    ⚠️ Certificate Status: EXPIRES SOON
+{{- end}}
+    Days Remaining: {{.CertRemainingDays}}
+{{- end}}
+{{- end}}
+{{- end}}
+
+📊 SUMMARY:
+{{repeat "=" 30}}
+Unavailable Endpoints: {{.Summary.Unavailable}}
+Degraded Endpoints: {{.Summary.Degraded}}
+Impacted Endpoints: {{.Summary.Impacted}}
+Recovered Endpoints: {{.Summary.Recovered}}
+Certificate Issues: {{.Summary.CertIssues}}
+Total Issues: {{.Summary.Total}}
+{{define "incident"}}
+{{- if .IsReminder}}
+    Still {{.Status}} for: {{duration .Duration}}
+{{- else if ne .PreviousStatus "up"}}
+    Previous Status: {{.PreviousStatus}}, incident ongoing for: {{duration .Duration}}
+{{- end}}
+{{- end}}`
+
+// defaultReportTemplate is the notify.txt report of the endpoints with problems in this round
+const defaultReportTemplate = `=== PongHub Service Status Report ===
+Generated at: {{.RunTime.Format "2006-01-02 15:04:05"}}
+{{- with .Down}}
+
+🔴 UNAVAILABLE SERVICES:
+{{repeat "=" 50}}
+{{- range .}}
+
+📋 Service: {{.Name}}
+{{- range .Endpoints}}
+  • URL: {{.URL}}
+    Method: {{.Method}}
+{{- if .StatusCode}}
+    Status Code: {{.StatusCode}}
+{{- end}}
+{{- if gt .ResponseTime 0}}
+    Response Time: {{.ResponseTime}}
+{{- end}}
+    Attempts: {{.SuccessNum}}/{{.AttemptNum}} successful
+    Check Time: {{.StartTime}} - {{.EndTime}}
+{{- with .FailureDetails}}
+    Failure Details:
+{{- range .}}
+      - {{.}}
+{{- end}}
+{{- end}}
+{{- if and .ResponseBody (lt (len .ResponseBody) 500)}}
+    Response Body: {{trim .ResponseBody}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- with .Degraded}}
+
+🐢 DEGRADED SERVICES:
+{{repeat "=" 50}}
+{{- range .}}
+
+📋 Service: {{.Name}}
+{{- range .Endpoints}}
+  • URL: {{.URL}}
+    Method: {{.Method}}
+    Response Time: {{.ResponseTime}}
+    Warn Latency: {{.WarnLatency}}
+    Check Time: {{.StartTime}} - {{.EndTime}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- with .Certificates}}
+
+🔐 CERTIFICATE ISSUES:
+{{repeat "=" 50}}
+{{- range .}}
+
+📋 Service: {{.Name}}
+{{- range .Endpoints}}
+  • URL: {{.URL}}
+{{- if .IsCertExpired}}
+    ❌ Certificate Status: EXPIRED
+{{- else if le .CertRemainingDays 1}}
+    🚨 Certificate Status: EXPIRES IN 1 DAY OR LESS
+{{- else}}
+    ⚠️  Certificate Status: EXPIRES SOON
+{{- end}}
+    Days Remaining: {{.CertRemainingDays}}
+{{- if .StatusCode}}
+    Status Code: {{.StatusCode}}
+{{- end}}
+{{- if gt .ResponseTime 0}}
+    Response Time: {{.ResponseTime}}
+{{- end}}
+    Check Time: {{.StartTime}} - {{.EndTime}}
+{{- end}}
+{{- end}}
+{{- end}}
+
+📊 SUMMARY:
+{{repeat "=" 50}}
+Unavailable Endpoints: {{.Summary.Unavailable}}
+Degraded Endpoints: {{.Summary.Degraded}}
+Certificate Issues: {{.Summary.CertIssues}}
+Total Issues: {{.Summary.Total}}
+`
+
+// defaultDigestTemplate is the message of digests, the failures of the endpoints are taken from the check log
+const defaultDigestTemplate = `Digest from {{.Since.Format "2006-01-02 15:04:05"}} to {{.Until.Format "2006-01-02 15:04:05"}}
+{{- with .Services}}
+
+🔔 SERVICE ALERTS:
+{{repeat "=" 30}}
+{{- range .}}
+
+📋 Service: {{.Name}}
+{{- range .Endpoints}}
+  • URL: {{.URL}}
+    Alerts: {{.Alerts}}, latest status: {{.Status}}
+{{- if .Downtime}}
+    Total Downtime: {{duration .Downtime}}
+{{- end}}
+{{- if .DegradedTime}}
+    Total Degraded Time: {{duration .DegradedTime}}
+{{- end}}
+{{- if not .FirstFailure.IsZero}}
+    First Failure: {{.FirstFailure.Format "2006-01-02 15:04:05"}}
+    Last Failure: {{.LastFailure.Format "2006-01-02 15:04:05"}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- with .Certificates}}
+
+🔒 CERTIFICATE ISSUES:
+{{repeat "=" 30}}
+{{- range .}}
+
+📋 Service: {{.Name}}
+{{- range .Endpoints}}
+  • URL: {{.URL}}
+{{- if .IsCertExpired}}
+    ❌ Certificate Status: EXPIRED
+{{- else}}
+    ⚠️  Certificate Status: EXPIRES SOON
+    Days Remaining: {{.CertRemainingDays}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- end}}
+`
+
+// templateFuncs are the functions available in notification templates besides the built-in ones
+var templateFuncs = template.FuncMap{
+	"duration": formatDuration,
+	"join":     strings.Join,
+	"repeat":   strings.Repeat,
+	"trim":     strings.TrimSpace,
+	"upper":    strings.ToUpper,
+	"lower":    strings.ToLower,
+}
+
+// Built-in templates, also used when a configured template fails
+var (
+	defaultTitle   = template.Must(template.New("title").Funcs(templateFuncs).Parse(defaultTitleTemplate))
+	defaultMessage = template.Must(template.New("message").Funcs(templateFuncs).Parse(defaultMessageTemplate))
+	defaultReport  = template.Must(template.New("report").Funcs(templateFuncs).Parse(defaultReportTemplate))
+	defaultDigest  = template.Must(template.New("digest").Funcs(templateFuncs).Parse(defaultDigestTemplate))
+)
+
+type (
+	// templateData is the data of the notification and report templates
+	templateData struct {
+		// Title is the rendered title, empty in the title template itself
+		Title string
+		// RunTime is when the checks were run
+		RunTime time.Time
+		// ReportURL is the configured status page URL
+		ReportURL string
+		// IsRecovery reports that every event is a recovery
+		IsRecovery bool
+		// Severity is the highest severity of the alerts, empty in the report
+		Severity     severity.Severity
+		Down         []templateService
+		Degraded     []templateService
+		Impacted     []templateService
+		Recovered    []templateService
+		Certificates []templateService
+		Summary      templateSummary
+	}
+
+	// templateService groups the endpoints of a service in a section
+	templateService struct {
+		Name      string
+		Endpoints []templateEndpoint
+		// ImpactedServices lists the services impacted by the outage of this service
+		ImpactedServices []string
+	}
+
+	// templateEndpoint is an endpoint with the alert it is notified for,
+	// the check result is available through the fields of checker.Endpoint such as .URL or .FailureDetails
+	templateEndpoint struct {
+		checker.Endpoint
+		Status         alert_status.AlertStatus
+		PreviousStatus alert_status.AlertStatus
+		IsReminder     bool
+		Duration       time.Duration
+		ImpactedBy     string
+		Severity       severity.Severity
+	}
+
+	// templateSummary counts the endpoints of every section
+	templateSummary struct {
+		Unavailable int
+		Degraded    int
+		Impacted    int
+		Recovered   int
+		CertIssues  int
+		Total       int
+	}
+
+	// channelTemplates holds the templates of a notification method, nil templates fall back to the global ones
+	channelTemplates struct {
+		title   *template.Template
+		message *template.Template
+	}
+
+	// messageTemplates holds the templates of the notification texts
+	messageTemplates struct {
+		title    *template.Template
+		message  *template.Template
+		report   *template.Template
+		digest   *template.Template
+		channels map[string]channelTemplates
+	}
+)
+
+// LastError returns the last failure of the endpoint
+func (e templateEndpoint) LastError() string {
+	if len(e.FailureDetails) == 0 {
+		return ""
+	}
+	return e.FailureDetails[len(e.FailureDetails)-1]
+}
+
+// loadMessageTemplates parses the configured template files. A template that cannot be read or parsed is logged
+// and replaced by the built-in one, so notifications are still sent.
+func loadMessageTemplates(config *configure.TemplatesConfig) *messageTemplates {
+	templates := &messageTemplates{title: defaultTitle, message: defaultMessage, report: defaultReport, digest: defaultDigest}
+	if config == nil {
+		return templates
+	}
+
+	templates.title = parseTemplateFile(config.Title, defaultTitle)
+	templates.message = parseTemplateFile(config.Message, defaultMessage)
+	templates.report = parseTemplateFile(config.Report, defaultReport)
+	templates.digest = parseTemplateFile(config.Digest, defaultDigest)
+	if len(config.Channels) > 0 {
+		templates.channels = make(map[string]channelTemplates)
+		for method, channel := range config.Channels {
+			templates.channels[strings.ToLower(method)] = channelTemplates{
+				title:   parseTemplateFile(channel.Title, nil),
+				message: parseTemplateFile(channel.Message, nil),
+			}
+		}
+	}
+	return templates
+}
+
+// parseTemplateFile parses the template file at path, returning fallback if path is empty or the file is invalid
+func parseTemplateFile(path string, fallback *template.Template) *template.Template {
+	if path == "" {
+		return fallback
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		log.Printf("Error reading notification template %s, using the default template: %v", path, err)
+		return fallback
+	}
+	tmpl, err := template.New(path).Funcs(templateFuncs).Parse(string(content))
+	if err != nil {
+		log.Printf("Error parsing notification template %s, using the default template: %v", path, err)
+		return fallback
+	}
+	return tmpl
+}
+
+// apply renders the title and message of the notification with the templates of the method
+func (t *messageTemplates) apply(method string, notification notifierTypes.Notification) notifierTypes.Notification {
+	titleTemplate, messageTemplate := t.title, t.message
+	if channel, ok := t.channels[strings.ToLower(method)]; ok {
+		if channel.title != nil {
+			titleTemplate = channel.title
+		}
+		if channel.message != nil {
+			messageTemplate = channel.message
+		}
+	}
+
+	data := newNotificationTemplateData(notification)
+	notification.Title = strings.TrimSpace(executeTemplate(titleTemplate, defaultTitle, data))
+	data.Title = notification.Title
+	notification.Message = executeTemplate(messageTemplate, defaultMessage, data)
+	return notification
+}
+
+// renderReport renders the notify.txt report
+func (t *messageTemplates) renderReport(data templateData) string {
+	return executeTemplate(t.report, defaultReport, data)
+}
+
+// renderDigest renders the title and message of a digest
+func (t *messageTemplates) renderDigest(data digestTemplateData) (string, string) {
+	return data.Title, executeTemplate(t.digest, defaultDigest, data)
+}
+
+// executeTemplate executes the template, falling back to the built-in template if it fails
+func executeTemplate(tmpl, fallback *template.Template, data any) string {
+	var text bytes.Buffer
+	err := tmpl.Execute(&text, data)
+	if err == nil {
+		return text.String()
+	}
+	log.Printf("Error executing notification template %s, using the default template: %v", tmpl.Name(), err)
+
+	text.Reset()
+	if err := fallback.Execute(&text, data); err != nil {
+		return fmt.Sprintf("failed to render notification: %v", err)
+	}
+	return text.String()
+}
+
+// newNotificationTemplateData converts the events and certificate problems of a notification into template data,
+// consecutive events of a service are grouped in check order
+func newNotificationTemplateData(notification notifierTypes.Notification) templateData {
+	data := templateData{
+		RunTime:      notification.GeneratedAt,
+		ReportURL:    notification.StatusPageURL,
+		IsRecovery:   len(notification.Events) > 0,
+		Severity:     notification.MaxSeverity(),
+		Certificates: newCertTemplateServices(notification.CertProblems),
+	}
+	for i := range data.Certificates {
+		for j := range data.Certificates[i].Endpoints {
+			endpoint := &data.Certificates[i].Endpoints[j]
+			endpoint.Severity = notification.CertSeverity(data.Certificates[i].Name, endpoint.Endpoint)
+		}
+	}
+
+	// impacted services are folded into the alert of their root cause, or listed on their own if it was notified before
+	impactedServices, unfoldedEvents := notifierTypes.FoldImpactedEvents(notification.Events)
+	for _, event := range notification.Events {
+		switch {
+		case event.IsImpacted():
+			data.Summary.Impacted++
+		case event.Status == alert_status.DOWN:
+			data.Down = appendTemplateEvent(data.Down, event, notification.EventSeverity(event))
+			data.Down[len(data.Down)-1].ImpactedServices = impactedServices[event.ServiceName]
+		case event.Status == alert_status.DEGRADED:
+			data.Degraded = appendTemplateEvent(data.Degraded, event, notification.EventSeverity(event))
+		default:
+			data.Recovered = appendTemplateEvent(data.Recovered, event, notification.EventSeverity(event))
+		}
+		if !event.IsRecovery() {
+			data.IsRecovery = false
+		}
+	}
+	for _, event := range unfoldedEvents {
+		data.Impacted = appendTemplateEvent(data.Impacted, event, notification.EventSeverity(event))
+	}
+
+	data.Summary.Unavailable = countTemplateEndpoints(data.Down)
+	data.Summary.Degraded = countTemplateEndpoints(data.Degraded)
+	data.Summary.Recovered = countTemplateEndpoints(data.Recovered)
+	data.Summary.CertIssues = countEndpoints(notification.CertProblems)
+	data.Summary.Total = data.Summary.Unavailable + data.Summary.Degraded + data.Summary.Impacted + data.Summary.CertIssues
+	return data
+}
+
+// newReportTemplateData collects the unavailable and degraded endpoints of the check results in check order
+func newReportTemplateData(checkResult []checker.Service, certProblemEndpoints map[string][]checker.Endpoint, now time.Time) templateData {
+	data := templateData{RunTime: now, Certificates: newCertTemplateServices(certProblemEndpoints)}
+	for _, serviceResult := range checkResult {
+		down := templateService{Name: serviceResult.Name}
+		degraded := templateService{Name: serviceResult.Name}
+		for _, endpointResult := range serviceResult.Endpoints {
+			switch endpointResult.Status {
+			case chk_result.NONE:
+				down.Endpoints = append(down.Endpoints, templateEndpoint{Endpoint: endpointResult})
+			case chk_result.DEGRADED:
+				degraded.Endpoints = append(degraded.Endpoints, templateEndpoint{Endpoint: endpointResult})
+			}
+		}
+		if len(down.Endpoints) > 0 {
+			data.Down = append(data.Down, down)
+		}
+		if len(degraded.Endpoints) > 0 {
+			data.Degraded = append(data.Degraded, degraded)
+		}
+	}
+
+	data.Summary.Unavailable = countTemplateEndpoints(data.Down)
+	data.Summary.Degraded = countTemplateEndpoints(data.Degraded)
+	data.Summary.CertIssues = countEndpoints(certProblemEndpoints)
+	data.Summary.Total = data.Summary.Unavailable + data.Summary.Degraded + data.Summary.CertIssues
+	return data
+}
+
+// appendTemplateEvent adds the endpoint of the event to the last service, or to a new service if the event is of another service
+func appendTemplateEvent(services []templateService, event notifierTypes.Event, level severity.Severity) []templateService {
+	if len(services) == 0 || services[len(services)-1].Name != event.ServiceName {
+		services = append(services, templateService{Name: event.ServiceName})
+	}
+	last := &services[len(services)-1]
+	last.Endpoints = append(last.Endpoints, templateEndpoint{
+		Endpoint:       event.Endpoint,
+		Status:         event.Status,
+		PreviousStatus: event.PreviousStatus,
+		IsReminder:     event.IsReminder,
+		Duration:       event.Duration,
+		ImpactedBy:     event.ImpactedBy,
+		Severity:       level,
+	})
+	return services
+}
+
+// newCertTemplateServices converts certificate problems into services sorted by name, map order is random
+func newCertTemplateServices(certProblemEndpoints map[string][]checker.Endpoint) []templateService {
+	serviceNames := make([]string, 0, len(certProblemEndpoints))
+	for serviceName := range certProblemEndpoints {
+		serviceNames = append(serviceNames, serviceName)
+	}
+	slices.Sort(serviceNames)
+
+	services := make([]templateService, 0, len(serviceNames))
+	for _, serviceName := range serviceNames {
+		service := templateService{Name: serviceName}
+		for _, endpoint := range certProblemEndpoints[serviceName] {
+			service.Endpoints = append(service.Endpoints, templateEndpoint{Endpoint: endpoint})
+		}
+		services = append(services, service)
+	}
+	return services
+}
+
+// countTemplateEndpoints counts the endpoints of the services
+func countTemplateEndpoints(services []templateService) int {
+	count := 0
+	for _, service := range services {
+		count += len(service.Endpoints)
+	}
+	return count
+}
@@ -0,0 +1,139 @@
+package notifier
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	notifierTypes "github.com/wcy-dt/ponghub/internal/types/structures/notifier"
+	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+)
+
+// writeTemplate writes a template file to a temporary directory and returns its path
+func writeTemplate(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	return path
+}
+
+// templateTestNotification is a notification with an outage, a recovery and an expiring certificate
+func templateTestNotification() notifierTypes.Notification {
+	return notifierTypes.Notification{
+		Events: []notifierTypes.Event{
+			{
+				ServiceName:    "Gateway",
+				Endpoint:       checker.Endpoint{URL: "https://gateway.example.com", FailureDetails: []string{"timeout", "connection refused"}},
+				Status:         "down",
+				PreviousStatus: "up",
+			},
+			{ServiceName: "Billing", Endpoint: checker.Endpoint{URL: "https://billing.example.com"}, Status: "up", PreviousStatus: "down", Duration: 5 * time.Minute},
+		},
+		CertProblems:  map[string][]checker.Endpoint{"Shop": {{URL: "https://shop.example.com", CertRemainingDays: 7}}},
+		GeneratedAt:   time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		StatusPageURL: "https://status.example.com",
+	}
+}
+
+func TestMessageTemplates_Custom(t *testing.T) {
+	templates := loadMessageTemplates(&configure.TemplatesConfig{
+		Title: writeTemplate(t, "title.tmpl", "[{{.Summary.Total}} issues] {{range .Down}}{{.Name}} {{end}}\n"),
+		Message: writeTemplate(t, "message.tmpl", `{{.Title}} at {{.RunTime.Format "15:04"}}
+{{range .Down}}{{range .Endpoints}}{{.URL}}: {{.LastError}}{{end}}{{end}}
+{{range .Recovered}}{{.Name}} back after {{duration (index .Endpoints 0).Duration}}{{end}}
+{{range .Certificates}}{{.Name}} expires in {{(index .Endpoints 0).CertRemainingDays}} days{{end}}
+{{.ReportURL}}`),
+		Channels: map[string]configure.ChannelTemplatesConfig{
+			"Email": {Title: writeTemplate(t, "email.tmpl", "{{upper (index .Down 0).Name}} is down")},
+		},
+	})
+
+	notification := templates.apply("webhook", templateTestNotification())
+	if notification.Title != "[2 issues] Gateway" {
+		t.Errorf("Expected the custom title without surrounding whitespace, got %q", notification.Title)
+	}
+	expected := "[2 issues] Gateway at 10:00\nhttps://gateway.example.com: connection refused\nBilling back after 5m0s\nShop expires in 7 days\nhttps://status.example.com"
+	if notification.Message != expected {
+		t.Errorf("Expected message %q, got %q", expected, notification.Message)
+	}
+
+	email := templates.apply("email", templateTestNotification())
+	if email.Title != "GATEWAY is down" {
+		t.Errorf("Expected the email title template, got %q", email.Title)
+	}
+	if !strings.HasPrefix(email.Message, "GATEWAY is down at 10:00\n") {
+		t.Errorf("Expected the email to use the global message template with its own title, got %q", email.Message)
+	}
+}
+
+func TestMessageTemplates_Fallback(t *testing.T) {
+	templates := loadMessageTemplates(&configure.TemplatesConfig{
+		Title:   filepath.Join(t.TempDir(), "missing.tmpl"),
+		Message: writeTemplate(t, "broken.tmpl", "{{range .Down}"),
+		Channels: map[string]configure.ChannelTemplatesConfig{
+			"slack": {Message: writeTemplate(t, "unknown.tmpl", "{{.Unknown}}")},
+		},
+	})
+
+	for _, method := range []string{"webhook", "slack"} {
+		notification := templates.apply(method, templateTestNotification())
+		if notification.Title != "🚨 PongHub Service Status Alert" {
+			t.Errorf("Expected %s to fall back to the default title, got %q", method, notification.Title)
+		}
+		if !strings.Contains(notification.Message, "🔴 UNAVAILABLE SERVICES:") || !strings.Contains(notification.Message, "Last Error: connection refused") {
+			t.Errorf("Expected %s to fall back to the default message, got:\n%s", method, notification.Message)
+		}
+	}
+}
+
+func TestMessageTemplates_RecoveryTitle(t *testing.T) {
+	notification := templateTestNotification()
+	notification.Events = notification.Events[1:]
+
+	if title := loadMessageTemplates(nil).apply("", notification).Title; title != "✅ PongHub Service Recovered" {
+		t.Errorf("Expected the recovery title, got %q", title)
+	}
+}
+
+func TestNotificationManager_ChannelTemplates(t *testing.T) {
+	plain, other := &plainService{}, &plainService{}
+	manager := &NotificationManager{
+		config:   &configure.NotificationConfig{Enabled: true},
+		services: []NotificationService{plain, other},
+		methods:  []string{"email", "webhook"},
+		templates: loadMessageTemplates(&configure.TemplatesConfig{
+			Channels: map[string]configure.ChannelTemplatesConfig{
+				"email": {Title: writeTemplate(t, "email.tmpl", "Subject: {{len .Down}} down")},
+			},
+		}),
+	}
+
+	manager.SendNotification(templateTestNotification())
+
+	if len(plain.titles) != 1 || plain.titles[0] != "Subject: 1 down" {
+		t.Errorf("Expected the email channel to use its title template, got %v", plain.titles)
+	}
+	if len(other.titles) != 1 || other.titles[0] != "🚨 PongHub Service Status Alert" {
+		t.Errorf("Expected the other channel to use the default title, got %v", other.titles)
+	}
+}
+
+func TestMessageTemplates_Report(t *testing.T) {
+	templates := loadMessageTemplates(&configure.TemplatesConfig{
+		Report: writeTemplate(t, "report.tmpl", "{{range .Down}}{{.Name}}:{{range .Endpoints}} {{.URL}} ({{join .FailureDetails \"; \"}}){{end}}{{end}}"),
+	})
+	checkResult := []checker.Service{{
+		Name:      "Gateway",
+		Endpoints: []checker.Endpoint{{URL: "https://gateway.example.com", Status: chk_result.NONE, FailureDetails: []string{"timeout", "refused"}}},
+	}}
+
+	report := templates.renderReport(newReportTemplateData(checkResult, nil, time.Now()))
+	if report != "Gateway: https://gateway.example.com (timeout; refused)" {
+		t.Errorf("Unexpected report %q", report)
+	}
+}
@@ -9,13 +9,14 @@ import (
 	"github.com/wcy-dt/ponghub/internal/common"
 	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
 	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
+	"github.com/wcy-dt/ponghub/internal/types/structures/notifier"
 	"github.com/wcy-dt/ponghub/internal/types/structures/reporter"
 	"github.com/wcy-dt/ponghub/internal/types/types/chk_result"
 	"github.com/wcy-dt/ponghub/internal/types/types/default_config"
 )
 
 // GetReport generates a report based on the check results and log data
-func GetReport(currentCheckResult []checker.Service, logPath string, cfg *configure.Configure) (reporter.Reporter, error) {
+func GetReport(currentCheckResult []checker.Service, logPath, statePath string, cfg *configure.Configure) (reporter.Reporter, error) {
 	// Load existing log data
 	previousLog, err := common.ReadLogs(logPath)
 	if err != nil {
@@ -41,6 +42,14 @@ func GetReport(currentCheckResult []checker.Service, logPath string, cfg *config
 	// calculate cert status
 	reportResult = getCertStatus(reportResult, currentCheckResult)
 
+	// show which endpoints are alerting or about to alert
+	notifyState, err := common.ReadNotifyState(statePath)
+	if err != nil {
+		log.Printf("Error loading notification state from %s: %v", statePath, err)
+		return nil, err
+	}
+	reportResult = getAlertStatus(reportResult, notifyState, cfg)
+
 	return reportResult, nil
 }
 
@@ -50,13 +59,23 @@ func getAvailability(reportResult reporter.Reporter) reporter.Reporter {
 		if len(reportResult[i].ServiceHistory) == 0 {
 			continue
 		}
-		statusAllEntryNum := 0
+		// degraded entries are slow but available, so they count as online,
+		// maintenance entries are planned and left out of the availability
+		statusOnlineEntryNum, countedEntryNum := 0, 0
 		for _, entry := range reportResult[i].ServiceHistory {
-			if chk_result.IsALL(entry.Status) {
-				statusAllEntryNum++
+			if chk_result.IsMaintenance(entry.Status) {
+				continue
+			}
+			countedEntryNum++
+			if chk_result.IsOnline(entry.Status) {
+				statusOnlineEntryNum++
 			}
 		}
-		availability := float64(statusAllEntryNum) / float64(len(reportResult[i].ServiceHistory))
+		if countedEntryNum == 0 {
+			reportResult[i].Availability = 1
+			continue
+		}
+		availability := float64(statusOnlineEntryNum) / float64(countedEntryNum)
 		reportResult[i].Availability = availability
 	}
 
@@ -94,6 +113,41 @@ func getCertStatus(reportResult reporter.Reporter, currentCheckResult []checker.
 	return reportResult
 }
 
+// getAlertStatus updates the report with the alert state of every endpoint
+func getAlertStatus(reportResult reporter.Reporter, notifyState notifier.State, cfg *configure.Configure) reporter.Reporter {
+	for i := range reportResult {
+		serviceState := notifyState[reportResult[i].Name]
+		for j := range reportResult[i].Endpoints {
+			endpoint := &reportResult[i].Endpoints[j]
+			endpointState, exists := serviceState[endpoint.URL]
+			if !exists {
+				continue
+			}
+			endpoint.IsAlerting = endpointState.IsAlerting()
+			endpoint.IsPendingAlert = endpointState.IsPendingAlert()
+			endpoint.Failures = endpointState.Failures
+			endpoint.AlertAfterFailures = getAlertAfterFailures(cfg, reportResult[i].Name, endpoint.URL)
+		}
+	}
+
+	return reportResult
+}
+
+// getAlertAfterFailures returns the configured alert_after_failures of an endpoint
+func getAlertAfterFailures(cfg *configure.Configure, serviceName, url string) int {
+	for _, service := range cfg.Services {
+		if service.Name != serviceName {
+			continue
+		}
+		for _, endpoint := range service.Endpoints {
+			if endpoint.URL == url {
+				return endpoint.AlertAfterFailures
+			}
+		}
+	}
+	return default_config.GetDefaultAlertAfterFailures()
+}
+
 // WriteReport generates an HTML report from the provided log data
 func WriteReport(reportResult reporter.Reporter, reportPath string, displayNum int) error {
 	// Parse the HTML template
@@ -0,0 +1,33 @@
+package reporter
+
+import (
+	"testing"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/reporter"
+)
+
+func TestGetAvailability(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []string
+		expected float64
+	}{
+		{name: "Online and degraded", statuses: []string{"all", "degraded", "part", "none"}, expected: 0.5},
+		{name: "Maintenance left out", statuses: []string{"all", "maintenance", "maintenance", "none"}, expected: 0.5},
+		{name: "Maintenance only", statuses: []string{"maintenance", "maintenance"}, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var history reporter.History
+			for _, status := range tt.statuses {
+				history = append(history, reporter.HistoryEntry{Status: status})
+			}
+
+			result := getAvailability(reporter.Reporter{{Name: "API", ServiceHistory: history}})
+			if result[0].Availability != tt.expected {
+				t.Errorf("Expected availability %v, got %v", tt.expected, result[0].Availability)
+			}
+		})
+	}
+}
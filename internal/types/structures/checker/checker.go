@@ -30,6 +30,7 @@ type (
 		StartTime         string                 `json:"start_time"`
 		EndTime           string                 `json:"end_time"`
 		ResponseTime      time.Duration          `json:"response_time"`
+		WarnLatency       time.Duration          `json:"warn_latency,omitempty"`
 		AttemptNum        int                    `json:"attempt_num"`
 		SuccessNum        int                    `json:"success_num"`
 		FailureDetails    []string               `json:"failure_details,omitempty"`
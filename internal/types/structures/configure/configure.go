@@ -1,14 +1,23 @@
 package configure
 
+import "time"
+
 type (
 	// Configure defines the overall configuration structure for the application
 	Configure struct {
-		Services       []Service           `yaml:"services"`
-		Timeout        int                 `yaml:"timeout,omitempty"`
-		MaxRetryTimes  int                 `yaml:"max_retry_times,omitempty"`
-		MaxLogDays     int                 `yaml:"max_log_days,omitempty"`
-		CertNotifyDays int                 `yaml:"cert_notify_days,omitempty"`
-		DisplayNum     int                 `yaml:"display_num,omitempty"`
-		Notifications  *NotificationConfig `yaml:"notifications,omitempty"`
+		Services       []Service     `yaml:"services"`
+		Timeout        int           `yaml:"timeout,omitempty"`
+		MaxRetryTimes  int           `yaml:"max_retry_times,omitempty"`
+		Concurrency    int           `yaml:"concurrency,omitempty"`
+		Interval       string        `yaml:"interval,omitempty"`
+		ParsedInterval time.Duration `yaml:"-"`
+		MaxLogDays     int           `yaml:"max_log_days,omitempty"`
+		CertNotifyDays int           `yaml:"cert_notify_days,omitempty"`
+		DisplayNum     int           `yaml:"display_num,omitempty"`
+		// AlertAfterFailures and RecoverAfterSuccesses are inherited by the services
+		AlertAfterFailures    int                 `yaml:"alert_after_failures,omitempty"`
+		RecoverAfterSuccesses int                 `yaml:"recover_after_successes,omitempty"`
+		Maintenance           MaintenanceWindows  `yaml:"maintenance,omitempty"`
+		Notifications         *NotificationConfig `yaml:"notifications,omitempty"`
 	}
 )
@@ -0,0 +1,50 @@
+package configure
+
+import (
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/common/cron"
+)
+
+type (
+	// MaintenanceWindow defines a planned maintenance period, either one-off with start and end,
+	// or recurring with a cron expression and a duration
+	MaintenanceWindow struct {
+		Name           string         `yaml:"name,omitempty"`
+		Start          string         `yaml:"start,omitempty"`
+		ParsedStart    time.Time      `yaml:"-"`
+		End            string         `yaml:"end,omitempty"`
+		ParsedEnd      time.Time      `yaml:"-"`
+		Cron           string         `yaml:"cron,omitempty"`
+		ParsedCron     *cron.Schedule `yaml:"-"`
+		Duration       string         `yaml:"duration,omitempty"`
+		ParsedDuration time.Duration  `yaml:"-"`
+		Timezone       string         `yaml:"timezone,omitempty"`
+		ParsedTimezone *time.Location `yaml:"-"`
+	}
+
+	// MaintenanceWindows defines the maintenance windows of a service
+	MaintenanceWindows []MaintenanceWindow
+)
+
+// IsActive checks if the maintenance window is open at the given time
+func (w MaintenanceWindow) IsActive(now time.Time) bool {
+	if w.ParsedCron != nil {
+		location := w.ParsedTimezone
+		if location == nil {
+			location = time.UTC
+		}
+		return w.ParsedCron.ActiveWithin(now.In(location), w.ParsedDuration)
+	}
+	return !now.Before(w.ParsedStart) && now.Before(w.ParsedEnd)
+}
+
+// IsActive checks if any of the maintenance windows is open at the given time
+func (windows MaintenanceWindows) IsActive(now time.Time) bool {
+	for _, window := range windows {
+		if window.IsActive(now) {
+			return true
+		}
+	}
+	return false
+}
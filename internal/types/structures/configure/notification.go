@@ -1,13 +1,93 @@
 package configure
 
+import "time"
+
 type (
 	// NotificationConfig defines the configuration for all notification channels
 	NotificationConfig struct {
-		Enabled bool           `yaml:"enabled,omitempty"`
-		Methods []string       `yaml:"methods,omitempty"`
-		Default *DefaultConfig `yaml:"default,omitempty"`
-		Email   *EmailConfig   `yaml:"email,omitempty"`
-		Webhook *WebhookConfig `yaml:"webhook,omitempty"`
+		Enabled                bool                    `yaml:"enabled,omitempty"`
+		Methods                []string                `yaml:"methods,omitempty"`
+		RenotifyInterval       string                  `yaml:"renotify_interval,omitempty"`
+		ParsedRenotifyInterval time.Duration           `yaml:"-"`
+		StatusPageURL          string                  `yaml:"status_page_url,omitempty"`
+		Templates              *TemplatesConfig        `yaml:"templates,omitempty"`
+		Routes                 []RouteConfig           `yaml:"routes,omitempty"`
+		Digests                map[string]DigestConfig `yaml:"digests,omitempty"`
+		Limits                 map[string]LimitsConfig `yaml:"limits,omitempty"`
+		Default                *DefaultConfig          `yaml:"default,omitempty"`
+		Email                  *EmailConfig            `yaml:"email,omitempty"`
+		Webhook                *WebhookConfig          `yaml:"webhook,omitempty"`
+		Slack                  *SlackConfig            `yaml:"slack,omitempty"`
+		Discord                *DiscordConfig          `yaml:"discord,omitempty"`
+		Teams                  *TeamsConfig            `yaml:"teams,omitempty"`
+		Telegram               *TelegramConfig         `yaml:"telegram,omitempty"`
+		PagerDuty              *PagerDutyConfig        `yaml:"pagerduty,omitempty"`
+		DingTalk               *DingTalkConfig         `yaml:"dingtalk,omitempty"`
+		Feishu                 *FeishuConfig           `yaml:"feishu,omitempty"`
+		WeCom                  *WeComConfig            `yaml:"wecom,omitempty"`
+		Ntfy                   *NtfyConfig             `yaml:"ntfy,omitempty"`
+		Gotify                 *GotifyConfig           `yaml:"gotify,omitempty"`
+	}
+
+	// TemplatesConfig defines the Go text/template files of the notification texts, empty fields use the built-in templates
+	TemplatesConfig struct {
+		Title   string `yaml:"title,omitempty"`
+		Message string `yaml:"message,omitempty"`
+		// Report is the template of the notify.txt report
+		Report string `yaml:"report,omitempty"`
+		// Digest is the template of the digest messages
+		Digest string `yaml:"digest,omitempty"`
+		// Channels overrides the title and message templates of notification methods
+		Channels map[string]ChannelTemplatesConfig `yaml:"channels,omitempty"`
+	}
+
+	// ChannelTemplatesConfig defines the templates of a notification method, empty fields use the global templates
+	ChannelTemplatesConfig struct {
+		Title   string `yaml:"title,omitempty"`
+		Message string `yaml:"message,omitempty"`
+	}
+
+	// RouteConfig sends the alerts it matches to its methods. Empty matchers match every alert, and all the set
+	// matchers must match. Routes are checked in order and the first matching route wins, unless it continues.
+	RouteConfig struct {
+		Services    []string `yaml:"services,omitempty"`
+		Tags        []string `yaml:"tags,omitempty"`
+		MinSeverity string   `yaml:"min_severity,omitempty"`
+		Methods     []string `yaml:"methods"`
+		// Continue also checks the following routes when this route matches
+		Continue bool `yaml:"continue,omitempty"`
+	}
+
+	// DigestConfig batches the alerts of a notification method into one summary per interval,
+	// the digests of the notification config are keyed by method
+	DigestConfig struct {
+		// Interval is "hourly", "daily" or a duration such as "12h"
+		Interval       string        `yaml:"interval"`
+		ParsedInterval time.Duration `yaml:"-"`
+		// CertificatesOnly batches only the certificate problems, the other alerts are still sent right away
+		CertificatesOnly bool `yaml:"certificates_only,omitempty"`
+	}
+
+	// LimitsConfig holds back the alerts of a notification method during its quiet hours and above its rate limit,
+	// the limits of the notification config are keyed by method.
+	// Held back alerts are queued and sent together once the method may send again.
+	LimitsConfig struct {
+		QuietHours *QuietHoursConfig `yaml:"quiet_hours,omitempty"`
+		// MaxPerHour is the maximum number of messages sent within an hour, 0 means no limit
+		MaxPerHour int `yaml:"max_per_hour,omitempty"`
+		// BypassSeverity sends the alerts of at least this severity right away, empty means that all alerts are held back
+		BypassSeverity string `yaml:"bypass_severity,omitempty"`
+	}
+
+	// QuietHoursConfig defines the daily period in which a notification method sends no alerts
+	QuietHoursConfig struct {
+		// Start and End are times of day such as "22:00", the quiet hours span midnight if End is before Start
+		Start          string         `yaml:"start"`
+		ParsedStart    time.Duration  `yaml:"-"`
+		End            string         `yaml:"end"`
+		ParsedEnd      time.Duration  `yaml:"-"`
+		Timezone       string         `yaml:"timezone,omitempty"`
+		ParsedTimezone *time.Location `yaml:"-"`
 	}
 
 	// EmailConfig defines SMTP email notification settings
@@ -20,6 +100,105 @@ type (
 		UseTLS      bool     `yaml:"use_tls,omitempty"`
 		UseStartTLS bool     `yaml:"use_starttls,omitempty"`
 		SkipVerify  bool     `yaml:"skip_verify,omitempty"`
+		// Auth is the SMTP auth mechanism: none, plain (default), login, cram-md5 or xoauth2
+		Auth string `yaml:"auth,omitempty"`
+		// Username, Password and Token support {{env(...)}}, the *File fields read them from files such as mounted secrets
+		Username     string `yaml:"username,omitempty"`
+		UsernameFile string `yaml:"username_file,omitempty"`
+		Password     string `yaml:"password,omitempty"`
+		PasswordFile string `yaml:"password_file,omitempty"`
+		// Token is the OAuth 2.0 access token of xoauth2
+		Token     string `yaml:"token,omitempty"`
+		TokenFile string `yaml:"token_file,omitempty"`
+		// Sparkline shows the recent checks of every endpoint in HTML emails
+		Sparkline bool `yaml:"sparkline,omitempty"`
+	}
+
+	// SlackConfig defines Slack incoming webhook notification settings
+	SlackConfig struct {
+		WebhookURL string `yaml:"webhook_url,omitempty"`
+		Channel    string `yaml:"channel,omitempty"`
+		Username   string `yaml:"username,omitempty"`
+		IconEmoji  string `yaml:"icon_emoji,omitempty"`
+		Retries    int    `yaml:"retries,omitempty"`
+		Timeout    int    `yaml:"timeout,omitempty"`
+	}
+
+	// DiscordConfig defines Discord webhook notification settings
+	DiscordConfig struct {
+		WebhookURL string `yaml:"webhook_url,omitempty"`
+		Username   string `yaml:"username,omitempty"`
+		AvatarURL  string `yaml:"avatar_url,omitempty"`
+		Retries    int    `yaml:"retries,omitempty"`
+		Timeout    int    `yaml:"timeout,omitempty"`
+	}
+
+	// TeamsConfig defines Microsoft Teams incoming webhook notification settings
+	TeamsConfig struct {
+		WebhookURL string `yaml:"webhook_url,omitempty"`
+		Retries    int    `yaml:"retries,omitempty"`
+		Timeout    int    `yaml:"timeout,omitempty"`
+	}
+
+	// TelegramConfig defines Telegram bot notification settings
+	TelegramConfig struct {
+		BotToken string   `yaml:"bot_token,omitempty"`
+		ChatIDs  []string `yaml:"chat_ids,omitempty"`
+		// SilentCertWarnings sends notifications that only warn about expiring certificates without sound
+		SilentCertWarnings bool   `yaml:"silent_cert_warnings,omitempty"`
+		APIURL             string `yaml:"api_url,omitempty"`
+		Retries            int    `yaml:"retries,omitempty"`
+		Timeout            int    `yaml:"timeout,omitempty"`
+	}
+
+	// PagerDutyConfig defines PagerDuty Events API v2 notification settings
+	PagerDutyConfig struct {
+		RoutingKey string `yaml:"routing_key,omitempty"`
+		// EventsURL allows sending the events to another service accepting the Events API v2
+		EventsURL string `yaml:"events_url,omitempty"`
+		Retries   int    `yaml:"retries,omitempty"`
+		Timeout   int    `yaml:"timeout,omitempty"`
+	}
+
+	// DingTalkConfig defines DingTalk group robot notification settings
+	DingTalkConfig struct {
+		WebhookURL string `yaml:"webhook_url,omitempty"`
+		Secret     string `yaml:"secret,omitempty"`
+		Retries    int    `yaml:"retries,omitempty"`
+		Timeout    int    `yaml:"timeout,omitempty"`
+	}
+
+	// FeishuConfig defines Feishu and Lark group robot notification settings
+	FeishuConfig struct {
+		WebhookURL string `yaml:"webhook_url,omitempty"`
+		Secret     string `yaml:"secret,omitempty"`
+		Retries    int    `yaml:"retries,omitempty"`
+		Timeout    int    `yaml:"timeout,omitempty"`
+	}
+
+	// WeComConfig defines WeCom group robot notification settings
+	WeComConfig struct {
+		WebhookURL string `yaml:"webhook_url,omitempty"`
+		Retries    int    `yaml:"retries,omitempty"`
+		Timeout    int    `yaml:"timeout,omitempty"`
+	}
+
+	// NtfyConfig defines ntfy push notification settings
+	NtfyConfig struct {
+		ServerURL string   `yaml:"server_url,omitempty"`
+		Topic     string   `yaml:"topic"`
+		Token     string   `yaml:"token,omitempty"`
+		Tags      []string `yaml:"tags,omitempty"`
+		Retries   int      `yaml:"retries,omitempty"`
+		Timeout   int      `yaml:"timeout,omitempty"`
+	}
+
+	// GotifyConfig defines Gotify push notification settings
+	GotifyConfig struct {
+		ServerURL string `yaml:"server_url"`
+		Token     string `yaml:"token,omitempty"`
+		Retries   int    `yaml:"retries,omitempty"`
+		Timeout   int    `yaml:"timeout,omitempty"`
 	}
 
 	// CustomPayloadConfig defines custom payload configuration for webhooks
@@ -48,5 +227,33 @@ type (
 		Retries       int                  `yaml:"retries,omitempty"`
 		Timeout       int                  `yaml:"timeout,omitempty"`
 		SkipTLSVerify bool                 `yaml:"skip_tls_verify,omitempty"`
+		// Signing signs the body of every request with HMAC, so receivers can check it comes from PongHub
+		Signing *WebhookSigningConfig `yaml:"signing,omitempty"`
+	}
+
+	// WebhookSigningConfig defines HMAC signing of webhook requests
+	WebhookSigningConfig struct {
+		// Secret supports {{env(...)}}, the WEBHOOK_SIGNING_SECRET environment variable is used if it is empty
+		Secret string `yaml:"secret,omitempty"`
+		// Algorithm is sha256 (default) or sha512
+		Algorithm string `yaml:"algorithm,omitempty"`
+		// Header carries the signature, X-PongHub-Signature by default
+		Header string `yaml:"header,omitempty"`
+		// TimestampHeader carries the signed Unix timestamp, X-PongHub-Timestamp by default
+		TimestampHeader string `yaml:"timestamp_header,omitempty"`
 	}
 )
+
+// IsActive checks if the quiet hours are ongoing at the given time
+func (q QuietHoursConfig) IsActive(now time.Time) bool {
+	location := q.ParsedTimezone
+	if location == nil {
+		location = time.UTC
+	}
+	local := now.In(location)
+	sinceMidnight := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute + time.Duration(local.Second())*time.Second
+	if q.ParsedStart <= q.ParsedEnd {
+		return sinceMidnight >= q.ParsedStart && sinceMidnight < q.ParsedEnd
+	}
+	return sinceMidnight >= q.ParsedStart || sinceMidnight < q.ParsedEnd
+}
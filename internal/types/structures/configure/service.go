@@ -1,25 +1,74 @@
 package configure
 
+import (
+	"regexp"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/common/jsonpath"
+)
+
 type (
 	// Service defines the configuration for a service, including its health and Endpoints ports
 	Service struct {
-		Name          string     `yaml:"name"`
-		Endpoints     []Endpoint `yaml:"endpoints"`
-		Timeout       int        `yaml:"timeout,omitempty"`
-		MaxRetryTimes int        `yaml:"max_retry_times,omitempty"`
+		Name           string        `yaml:"name"`
+		Endpoints      []Endpoint    `yaml:"endpoints"`
+		Timeout        int           `yaml:"timeout,omitempty"`
+		MaxRetryTimes  int           `yaml:"max_retry_times,omitempty"`
+		Concurrency    int           `yaml:"concurrency,omitempty"`
+		Interval       string        `yaml:"interval,omitempty"`
+		ParsedInterval time.Duration `yaml:"-"`
+		// AlertAfterFailures and RecoverAfterSuccesses are inherited by the endpoints
+		AlertAfterFailures    int `yaml:"alert_after_failures,omitempty"`
+		RecoverAfterSuccesses int `yaml:"recover_after_successes,omitempty"`
+		// Maintenance includes the global maintenance windows once the config is loaded
+		Maintenance MaintenanceWindows `yaml:"maintenance,omitempty"`
+		// DependsOn names the services this service needs, their outages explain the outages of this service
+		DependsOn []string `yaml:"depends_on,omitempty"`
+		// Tags and Severity are matched by the notification routes, Severity is also sent with the alerts
+		Tags     []string `yaml:"tags,omitempty"`
+		Severity string   `yaml:"severity,omitempty"`
 	}
 
 	// Endpoint defines the configuration for a port
 	Endpoint struct {
-		URL                 string            `yaml:"url"`
-		ParsedURL           string            `yaml:"-"`
-		Method              string            `yaml:"method,omitempty"`
-		Headers             map[string]string `yaml:"headers,omitempty"`
-		ParsedHeaders       map[string]string `yaml:"-"`
-		Body                string            `yaml:"body,omitempty"`
-		ParsedBody          string            `yaml:"-"`
-		StatusCode          int               `yaml:"status_code,omitempty"`
-		ResponseRegex       string            `yaml:"response_regex,omitempty"`
-		ParsedResponseRegex string            `yaml:"-"`
+		Type                  string                `yaml:"type,omitempty"`
+		URL                   string                `yaml:"url"`
+		ParsedURL             string                `yaml:"-"`
+		Method                string                `yaml:"method,omitempty"`
+		Headers               map[string]string     `yaml:"headers,omitempty"`
+		ParsedHeaders         map[string]string     `yaml:"-"`
+		Body                  string                `yaml:"body,omitempty"`
+		ParsedBody            string                `yaml:"-"`
+		StatusCode            StatusCodes           `yaml:"status_code,omitempty"`
+		ResponseRegex         string                `yaml:"response_regex,omitempty"`
+		ParsedResponseRegex   string                `yaml:"-"`
+		JSONAssertions        []string              `yaml:"json_assertions,omitempty"`
+		ParsedJSONAssertions  []*jsonpath.Assertion `yaml:"-"`
+		HeaderAssertions      []HeaderAssertion     `yaml:"header_assertions,omitempty"`
+		Send                  string                `yaml:"send,omitempty"`
+		ParsedSend            string                `yaml:"-"`
+		Expect                string                `yaml:"expect,omitempty"`
+		ParsedExpect          string                `yaml:"-"`
+		Resolver              string                `yaml:"resolver,omitempty"`
+		RecordType            string                `yaml:"record_type,omitempty"`
+		ExpectValues          []string              `yaml:"expect_values,omitempty"`
+		WarnLatency           string                `yaml:"warn_latency,omitempty"`
+		ParsedWarnLatency     time.Duration         `yaml:"-"`
+		MaxLatency            string                `yaml:"max_latency,omitempty"`
+		ParsedMaxLatency      time.Duration         `yaml:"-"`
+		AlertAfterFailures    int                   `yaml:"alert_after_failures,omitempty"`
+		RecoverAfterSuccesses int                   `yaml:"recover_after_successes,omitempty"`
+	}
+
+	// HeaderAssertion defines a check on a response header, exactly one of the checks must be set
+	HeaderAssertion struct {
+		Name     string `yaml:"name"`
+		Equals   string `yaml:"equals,omitempty"`
+		Regex    string `yaml:"regex,omitempty"`
+		NotRegex string `yaml:"not_regex,omitempty"`
+		Present  bool   `yaml:"present,omitempty"`
+		Absent   bool   `yaml:"absent,omitempty"`
+		// ParsedRegex is the compiled regex or not_regex, compiled once when the config is loaded
+		ParsedRegex *regexp.Regexp `yaml:"-"`
 	}
 )
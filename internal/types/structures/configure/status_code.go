@@ -0,0 +1,112 @@
+package configure
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// StatusCodeRange defines an inclusive range of accepted HTTP status codes
+	StatusCodeRange struct {
+		Min int
+		Max int
+	}
+
+	// StatusCodes defines the accepted HTTP status codes of an endpoint.
+	// It is written as a single code (`200`), a class (`2xx`), a range (`301-308`) or a list of them.
+	StatusCodes []StatusCodeRange
+)
+
+// UnmarshalYAML decodes a single status code specification or a list of them
+func (s *StatusCodes) UnmarshalYAML(value *yaml.Node) error {
+	var specs []string
+	switch value.Kind {
+	case yaml.ScalarNode:
+		specs = []string{value.Value}
+	case yaml.SequenceNode:
+		for _, item := range value.Content {
+			if item.Kind != yaml.ScalarNode {
+				return fmt.Errorf("line %d: status_code entries must be scalars", item.Line)
+			}
+			specs = append(specs, item.Value)
+		}
+	default:
+		return fmt.Errorf("line %d: status_code must be a code, a range or a list", value.Line)
+	}
+
+	var codes StatusCodes
+	for _, spec := range specs {
+		codeRange, err := ParseStatusCodeRange(spec)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", value.Line, err)
+		}
+		codes = append(codes, codeRange)
+	}
+	*s = codes
+	return nil
+}
+
+// ParseStatusCodeRange parses `200`, `2xx` or `301-308`
+func ParseStatusCodeRange(spec string) (StatusCodeRange, error) {
+	spec = strings.ToLower(strings.TrimSpace(spec))
+
+	if len(spec) == 3 && strings.HasSuffix(spec, "xx") && spec[0] >= '1' && spec[0] <= '5' {
+		class := int(spec[0]-'0') * 100
+		return StatusCodeRange{Min: class, Max: class + 99}, nil
+	}
+
+	if lower, upper, found := strings.Cut(spec, "-"); found {
+		minCode, err1 := parseStatusCode(lower)
+		maxCode, err2 := parseStatusCode(upper)
+		if err1 != nil || err2 != nil || minCode > maxCode {
+			return StatusCodeRange{}, fmt.Errorf("invalid status code range %q", spec)
+		}
+		return StatusCodeRange{Min: minCode, Max: maxCode}, nil
+	}
+
+	code, err := parseStatusCode(spec)
+	if err != nil {
+		return StatusCodeRange{}, err
+	}
+	return StatusCodeRange{Min: code, Max: code}, nil
+}
+
+// parseStatusCode parses a single HTTP status code
+func parseStatusCode(s string) (int, error) {
+	code, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || code < 100 || code > 599 {
+		return 0, fmt.Errorf("invalid status code %q", s)
+	}
+	return code, nil
+}
+
+// IsEmpty checks if no status code is configured
+func (s StatusCodes) IsEmpty() bool {
+	return len(s) == 0
+}
+
+// Contains checks if the status code is accepted
+func (s StatusCodes) Contains(code int) bool {
+	for _, codeRange := range s {
+		if code >= codeRange.Min && code <= codeRange.Max {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns a readable representation such as `200, 301-308`
+func (s StatusCodes) String() string {
+	parts := make([]string, 0, len(s))
+	for _, codeRange := range s {
+		if codeRange.Min == codeRange.Max {
+			parts = append(parts, strconv.Itoa(codeRange.Min))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", codeRange.Min, codeRange.Max))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,90 @@
+package configure
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestStatusCodes_UnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		accepted []int
+		rejected []int
+	}{
+		{
+			name:     "Single code",
+			input:    "status_code: 200",
+			accepted: []int{200},
+			rejected: []int{201, 204},
+		},
+		{
+			name:     "List of codes",
+			input:    "status_code: [200, 204]",
+			accepted: []int{200, 204},
+			rejected: []int{201, 301},
+		},
+		{
+			name:     "Class",
+			input:    "status_code: 2xx",
+			accepted: []int{200, 226, 299},
+			rejected: []int{199, 300},
+		},
+		{
+			name:     "Range",
+			input:    "status_code: 301-308",
+			accepted: []int{301, 304, 308},
+			rejected: []int{300, 309},
+		},
+		{
+			name:     "Mixed list",
+			input:    "status_code: [2xx, \"301-302\", 418]",
+			accepted: []int{204, 301, 302, 418},
+			rejected: []int{303, 404},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var endpoint Endpoint
+			if err := yaml.Unmarshal([]byte(tt.input), &endpoint); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			for _, code := range tt.accepted {
+				if !endpoint.StatusCode.Contains(code) {
+					t.Errorf("Expected %d to be accepted by %s", code, endpoint.StatusCode)
+				}
+			}
+			for _, code := range tt.rejected {
+				if endpoint.StatusCode.Contains(code) {
+					t.Errorf("Expected %d to be rejected by %s", code, endpoint.StatusCode)
+				}
+			}
+		})
+	}
+}
+
+func TestStatusCodes_UnmarshalYAMLInvalid(t *testing.T) {
+	inputs := []string{
+		"status_code: abc",
+		"status_code: 9xx",
+		"status_code: 308-301",
+		"status_code: 1000",
+		"status_code: {code: 200}",
+	}
+
+	for _, input := range inputs {
+		var endpoint Endpoint
+		if err := yaml.Unmarshal([]byte(input), &endpoint); err == nil {
+			t.Errorf("Expected an error for %q", input)
+		}
+	}
+}
+
+func TestStatusCodes_String(t *testing.T) {
+	codes := StatusCodes{{Min: 200, Max: 200}, {Min: 301, Max: 308}}
+	if got := codes.String(); got != "200, 301-308" {
+		t.Errorf("String() = %q, want %q", got, "200, 301-308")
+	}
+}
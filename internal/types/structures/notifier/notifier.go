@@ -0,0 +1,201 @@
+package notifier
+
+import (
+	"slices"
+	"time"
+
+	"github.com/wcy-dt/ponghub/internal/types/structures/checker"
+	"github.com/wcy-dt/ponghub/internal/types/structures/logger"
+	"github.com/wcy-dt/ponghub/internal/types/types/alert_status"
+	"github.com/wcy-dt/ponghub/internal/types/types/severity"
+)
+
+type (
+	// EndpointState is the alert state of an endpoint persisted between runs
+	EndpointState struct {
+		Status string `json:"status"`
+		// Since is when the endpoint went from up to a problem, or back to up.
+		// Changes between degraded and down keep the start of the incident.
+		Since            string `json:"since"`
+		LastNotified     string `json:"last_notified,omitempty"`
+		CertLastNotified string `json:"cert_last_notified,omitempty"`
+		// Failures and Successes count the consecutive failed and successful checks since StreakSince
+		Failures    int    `json:"failures,omitempty"`
+		Successes   int    `json:"successes,omitempty"`
+		StreakSince string `json:"streak_since,omitempty"`
+	}
+
+	// ServiceState maps endpoint URLs to their alert state
+	ServiceState map[string]EndpointState
+
+	// State maps service names to the alert state of their endpoints
+	State map[string]ServiceState
+
+	// DigestAlert is an endpoint with alerts collected for the pending digest of a notification method
+	DigestAlert struct {
+		ServiceName string `json:"service"`
+		URL         string `json:"url"`
+		// Certificate marks certificate problems, the other alerts are changes of the alert state
+		Certificate bool `json:"certificate,omitempty"`
+		// Status is the alert status of the latest alert
+		Status            string `json:"status,omitempty"`
+		CertRemainingDays int    `json:"cert_remaining_days,omitempty"`
+		IsCertExpired     bool   `json:"is_cert_expired,omitempty"`
+		// Alerts counts the alerts collected for the endpoint
+		Alerts int `json:"alerts"`
+	}
+
+	// ChannelState is the state of a notification method persisted between runs
+	ChannelState struct {
+		// DigestSince is when the first alert of the pending digest was collected
+		DigestSince  string        `json:"digest_since,omitempty"`
+		DigestAlerts []DigestAlert `json:"digest_alerts,omitempty"`
+		// QueuedEvents and QueuedCertProblems are held back by the quiet hours or the rate limit until they can be sent
+		QueuedEvents       []Event                       `json:"queued_events,omitempty"`
+		QueuedCertProblems map[string][]checker.Endpoint `json:"queued_cert_problems,omitempty"`
+		// SentAt holds when the messages of the last hour were sent, for the rate limit
+		SentAt []string `json:"sent_at,omitempty"`
+	}
+
+	// ChannelStates maps notification methods to their state
+	ChannelStates map[string]ChannelState
+
+	// Event is a notified change of the alert state of an endpoint, or a reminder of an ongoing incident
+	Event struct {
+		ServiceName    string                   `json:"service"`
+		Endpoint       checker.Endpoint         `json:"endpoint"`
+		Status         alert_status.AlertStatus `json:"status"`
+		PreviousStatus alert_status.AlertStatus `json:"previous_status"`
+		IsReminder     bool                     `json:"is_reminder,omitempty"`
+		// Duration is how long the incident has lasted, or lasted for recoveries
+		Duration time.Duration `json:"duration,omitempty"`
+		// ImpactedBy names the down service this service depends on whose outage is the root cause of the event
+		ImpactedBy string `json:"impacted_by,omitempty"`
+	}
+
+	// Notification is a notification with its plain text message and the data it was generated from,
+	// so channels can format it themselves
+	Notification struct {
+		Title         string
+		Message       string
+		Events        []Event
+		CertProblems  map[string][]checker.Endpoint
+		GeneratedAt   time.Time
+		StatusPageURL string
+		// History is the check log before this round, for channels showing the recent history of the endpoints
+		History logger.Logger
+		// Severities maps the services to their configured severity, the alerts of other services get the severity of their problem
+		Severities map[string]severity.Severity
+		// CertRecoveries holds the endpoints whose notified certificate problem is fixed
+		CertRecoveries map[string][]checker.Endpoint
+		// Removed holds the state of the endpoints removed from the configuration while they were alerting
+		// or had a notified certificate problem, so their incidents can be closed
+		Removed State
+	}
+)
+
+// IsAlerting checks if the endpoint has reached its failure threshold and is alerting
+func (s EndpointState) IsAlerting() bool {
+	return alert_status.ParseAlertStatus(s.Status) != alert_status.UP
+}
+
+// IsPendingAlert checks if the endpoint fails but has not reached its failure threshold yet
+func (s EndpointState) IsPendingAlert() bool {
+	return !s.IsAlerting() && s.Failures > 0
+}
+
+// IsEmpty checks if the channel state holds nothing to keep between runs
+func (s ChannelState) IsEmpty() bool {
+	return s.DigestSince == "" && len(s.DigestAlerts) == 0 && !s.HasQueuedAlerts() && len(s.SentAt) == 0
+}
+
+// HasQueuedAlerts checks if alerts are held back until the notification method can send again
+func (s ChannelState) HasQueuedAlerts() bool {
+	return len(s.QueuedEvents) > 0 || len(s.QueuedCertProblems) > 0
+}
+
+// IsRecovery checks if the event reports an endpoint that is up again
+func (e Event) IsRecovery() bool {
+	return e.Status == alert_status.UP
+}
+
+// IsImpacted checks if the event is explained by the outage of a service it depends on
+func (e Event) IsImpacted() bool {
+	return e.ImpactedBy != ""
+}
+
+// EventSeverity returns the configured severity of the service of the event, or the severity of its problem.
+// Recoveries get the severity of the incident they end, so they reach the channels the incident was sent to.
+func (n Notification) EventSeverity(event Event) severity.Severity {
+	if level, ok := n.Severities[event.ServiceName]; ok && level != "" {
+		return level
+	}
+	status := event.Status
+	if event.IsRecovery() {
+		status = event.PreviousStatus
+	}
+	switch {
+	case status == alert_status.DEGRADED:
+		return severity.WARNING
+	case event.IsImpacted():
+		return severity.ERROR
+	case status == alert_status.DOWN:
+		return severity.CRITICAL
+	default:
+		return severity.INFO
+	}
+}
+
+// CertSeverity returns the configured severity of the service, or the severity of the certificate problem of the endpoint
+func (n Notification) CertSeverity(serviceName string, endpoint checker.Endpoint) severity.Severity {
+	if level, ok := n.Severities[serviceName]; ok && level != "" {
+		return level
+	}
+	if endpoint.IsCertExpired {
+		return severity.ERROR
+	}
+	return severity.WARNING
+}
+
+// MaxSeverity returns the highest severity of the events and certificate problems of the notification
+func (n Notification) MaxSeverity() severity.Severity {
+	highest := severity.INFO
+	for _, event := range n.Events {
+		if level := n.EventSeverity(event); !highest.AtLeast(level) {
+			highest = level
+		}
+	}
+	for serviceName, endpoints := range n.CertProblems {
+		for _, endpoint := range endpoints {
+			if level := n.CertSeverity(serviceName, endpoint); !highest.AtLeast(level) {
+				highest = level
+			}
+		}
+	}
+	return highest
+}
+
+// FoldImpactedEvents groups the impacted events by their root cause. Services impacted by a root cause that is
+// down in the same events are folded into its alert and returned by root cause, the other impacted events are returned as is.
+func FoldImpactedEvents(events []Event) (map[string][]string, []Event) {
+	isNotifiedDown := make(map[string]bool)
+	for _, event := range events {
+		if !event.IsImpacted() && event.Status == alert_status.DOWN {
+			isNotifiedDown[event.ServiceName] = true
+		}
+	}
+
+	impactedServices := make(map[string][]string)
+	var unfoldedEvents []Event
+	for _, event := range events {
+		if !event.IsImpacted() {
+			continue
+		}
+		if !isNotifiedDown[event.ImpactedBy] {
+			unfoldedEvents = append(unfoldedEvents, event)
+		} else if !slices.Contains(impactedServices[event.ImpactedBy], event.ServiceName) {
+			impactedServices[event.ImpactedBy] = append(impactedServices[event.ImpactedBy], event.ServiceName)
+		}
+	}
+	return impactedServices, unfoldedEvents
+}
@@ -14,13 +14,17 @@ type (
 	History []HistoryEntry
 
 	Endpoint struct {
-		URL               string // Added URL field to store the endpoint URL
-		EndpointHistory   History
-		IsHTTPS           bool
-		IsCertExpired     bool
-		CertRemainingDays int
-		DisplayURL        string              // Resolved URL for display
-		HighlightSegments []highlight.Segment // Segments with highlight info
+		URL                string // Added URL field to store the endpoint URL
+		EndpointHistory    History
+		IsHTTPS            bool
+		IsCertExpired      bool
+		CertRemainingDays  int
+		DisplayURL         string              // Resolved URL for display
+		HighlightSegments  []highlight.Segment // Segments with highlight info
+		IsAlerting         bool                // Failed often enough to alert
+		IsPendingAlert     bool                // Failing, but below alert_after_failures
+		Failures           int                 // Consecutive failed checks
+		AlertAfterFailures int                 // Failed checks needed to alert
 	}
 
 	// Endpoints is a slice of Endpoint
@@ -32,6 +36,8 @@ type (
 		ServiceHistory History
 		Availability   float64
 		Endpoints      Endpoints
+		DependsOn      []string // Services this service depends on
+		Dependents     []string // Services depending on this service
 	}
 
 	// Reporter is a slice of Service
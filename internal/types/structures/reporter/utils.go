@@ -2,6 +2,7 @@ package reporter
 
 import (
 	"log"
+	"slices"
 	"sort"
 
 	"github.com/wcy-dt/ponghub/internal/types/structures/configure"
@@ -90,8 +91,23 @@ func ParseLogResult(logResult logger.Logger, serviceNames []string, cfg *configu
 			Name:           serviceName,
 			ServiceHistory: serviceHistory,
 			Endpoints:      endpoints,
+			Dependents:     getDependents(serviceName, cfg),
+		}
+		if serviceConfig != nil {
+			newService.DependsOn = serviceConfig.DependsOn
 		}
 		report = append(report, newService)
 	}
 	return report
 }
+
+// getDependents returns the services that depend on the given service, in config order
+func getDependents(serviceName string, cfg *configure.Configure) []string {
+	var dependents []string
+	for _, service := range cfg.Services {
+		if slices.Contains(service.DependsOn, serviceName) {
+			dependents = append(dependents, service.Name)
+		}
+	}
+	return dependents
+}
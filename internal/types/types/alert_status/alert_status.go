@@ -0,0 +1,46 @@
+package alert_status
+
+import "github.com/wcy-dt/ponghub/internal/types/types/chk_result"
+
+type AlertStatus string
+
+const (
+	// UP represents an endpoint that answers as expected
+	UP AlertStatus = "up"
+
+	// DEGRADED represents an endpoint that answers slower than its latency threshold
+	DEGRADED AlertStatus = "degraded"
+
+	// DOWN represents an endpoint that does not answer
+	DOWN AlertStatus = "down"
+)
+
+// String returns the string representation of the AlertStatus
+func (s AlertStatus) String() string {
+	return string(s)
+}
+
+// ParseAlertStatus parses a string into an AlertStatus, unknown values are treated as UP
+func ParseAlertStatus(s string) AlertStatus {
+	switch s {
+	case "degraded":
+		return DEGRADED
+	case "down":
+		return DOWN
+	default:
+		return UP
+	}
+}
+
+// FromCheckResult converts the check result of an endpoint into its alert status.
+// Endpoints that only succeeded after retries are considered up.
+func FromCheckResult(result chk_result.CheckResult) AlertStatus {
+	switch result {
+	case chk_result.NONE:
+		return DOWN
+	case chk_result.DEGRADED:
+		return DEGRADED
+	default:
+		return UP
+	}
+}
@@ -6,12 +6,18 @@ const (
 	// ALL represents all ports are online
 	ALL CheckResult = "all"
 
+	// DEGRADED represents all ports are online, but some of them respond slower than their latency threshold
+	DEGRADED CheckResult = "degraded"
+
 	// PART represents some ports are online
 	PART CheckResult = "part"
 
 	// NONE represents no ports are online
 	NONE CheckResult = "none"
 
+	// MAINTENANCE represents a check during a maintenance window, it counts neither as up nor as down
+	MAINTENANCE CheckResult = "maintenance"
+
 	// UNKNOWN represents an unknown test result
 	UNKNOWN CheckResult = "unknown"
 )
@@ -21,10 +27,14 @@ func (tr CheckResult) String() string {
 	switch tr {
 	case ALL:
 		return "all"
+	case DEGRADED:
+		return "degraded"
 	case PART:
 		return "part"
 	case NONE:
 		return "none"
+	case MAINTENANCE:
+		return "maintenance"
 	default:
 		return "unknown"
 	}
@@ -32,7 +42,12 @@ func (tr CheckResult) String() string {
 
 // IsValid checks if the CheckResult is valid
 func (tr CheckResult) IsValid() bool {
-	return tr == ALL || tr == PART || tr == NONE
+	return tr == ALL || tr == DEGRADED || tr == PART || tr == NONE || tr == MAINTENANCE
+}
+
+// IsOnline checks if the CheckResult means every port answered, slow ports included
+func (tr CheckResult) IsOnline() bool {
+	return tr == ALL || tr == DEGRADED
 }
 
 // IsALL checks if the CheckResult is ALL
@@ -40,15 +55,29 @@ func IsALL(resultStr string) bool {
 	return ParseCheckResult(resultStr) == ALL
 }
 
+// IsOnline checks if the CheckResult string means every port answered, slow ports included
+func IsOnline(resultStr string) bool {
+	return ParseCheckResult(resultStr).IsOnline()
+}
+
+// IsMaintenance checks if the CheckResult string is MAINTENANCE
+func IsMaintenance(resultStr string) bool {
+	return ParseCheckResult(resultStr) == MAINTENANCE
+}
+
 // ParseCheckResult parses a string into a CheckResult
 func ParseCheckResult(s string) CheckResult {
 	switch s {
 	case "all":
 		return ALL
+	case "degraded":
+		return DEGRADED
 	case "part":
 		return PART
 	case "none":
 		return NONE
+	case "maintenance":
+		return MAINTENANCE
 	default:
 		return UNKNOWN
 	}
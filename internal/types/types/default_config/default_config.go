@@ -12,6 +12,18 @@ const (
 
 	// certNotifyDays is the default number of days to notify before certificate expiration
 	certNotifyDays = 7
+
+	// concurrency is the default number of endpoints checked in parallel
+	concurrency = 8
+
+	// interval is the default interval between two checks of a service in daemon mode
+	interval = "5m"
+
+	// alertAfterFailures is the default number of consecutive failed checks before an endpoint alerts
+	alertAfterFailures = 1
+
+	// recoverAfterSuccesses is the default number of consecutive successful checks before an endpoint recovers
+	recoverAfterSuccesses = 1
 )
 
 // GetDefaultTimeout returns the default timeout for service checks
@@ -34,6 +46,26 @@ func GetDefaultCertNotifyDays() int {
 	return certNotifyDays
 }
 
+// GetDefaultConcurrency returns the default number of endpoints checked in parallel
+func GetDefaultConcurrency() int {
+	return concurrency
+}
+
+// GetDefaultInterval returns the default interval between two checks of a service in daemon mode
+func GetDefaultInterval() string {
+	return interval
+}
+
+// GetDefaultAlertAfterFailures returns the default number of consecutive failed checks before an endpoint alerts
+func GetDefaultAlertAfterFailures() int {
+	return alertAfterFailures
+}
+
+// GetDefaultRecoverAfterSuccesses returns the default number of consecutive successful checks before an endpoint recovers
+func GetDefaultRecoverAfterSuccesses() int {
+	return recoverAfterSuccesses
+}
+
 // SetDefaultTimeout sets the default timeout for a given configuration pointer
 func SetDefaultTimeout(cfg *int) {
 	if *cfg <= 0 {
@@ -62,6 +94,34 @@ func SetDefaultCertNotifyDays(cfg *int) {
 	}
 }
 
+// SetDefaultConcurrency sets the default number of endpoints checked in parallel for a given configuration pointer
+func SetDefaultConcurrency(cfg *int) {
+	if *cfg <= 0 {
+		*cfg = GetDefaultConcurrency()
+	}
+}
+
+// SetDefaultAlertAfterFailures sets the default number of consecutive failed checks before alerting for a given configuration pointer
+func SetDefaultAlertAfterFailures(cfg *int) {
+	if *cfg <= 0 {
+		*cfg = GetDefaultAlertAfterFailures()
+	}
+}
+
+// SetDefaultRecoverAfterSuccesses sets the default number of consecutive successful checks before recovering for a given configuration pointer
+func SetDefaultRecoverAfterSuccesses(cfg *int) {
+	if *cfg <= 0 {
+		*cfg = GetDefaultRecoverAfterSuccesses()
+	}
+}
+
+// SetDefaultInterval sets the default interval between two checks of a service for a given configuration pointer
+func SetDefaultInterval(cfg *string) {
+	if *cfg == "" {
+		*cfg = GetDefaultInterval()
+	}
+}
+
 const (
 	// displayNum is the default number of logs per endpoint to display in the HTML report
 	displayNum = 72
@@ -94,6 +154,12 @@ const (
 
 	// notifyPath is the default path to the notification template file
 	notifyPath = "data/notify.txt"
+
+	// notifyStatePath is the default path to the alert state persisted between runs
+	notifyStatePath = "data/notify_state.json"
+
+	// channelStatePath is the default path to the state of the notification methods persisted between runs
+	channelStatePath = "data/notify_channels.json"
 )
 
 // GetConfigPath returns the default path to the configuration file
@@ -120,3 +186,13 @@ func GetTemplatePath() string {
 func GetNotifyPath() string {
 	return notifyPath
 }
+
+// GetNotifyStatePath returns the default path to the alert state persisted between runs
+func GetNotifyStatePath() string {
+	return notifyStatePath
+}
+
+// GetChannelStatePath returns the default path to the state of the notification methods persisted between runs
+func GetChannelStatePath() string {
+	return channelStatePath
+}
@@ -0,0 +1,43 @@
+package endpoint_type
+
+import "strings"
+
+type EndpointType string
+
+const (
+	// HTTP represents an HTTP(S) endpoint
+	HTTP EndpointType = "http"
+
+	// TCP represents a plain TCP port
+	TCP EndpointType = "tcp"
+
+	// DNS represents a DNS name resolved through a resolver
+	DNS EndpointType = "dns"
+
+	// UNKNOWN represents an unsupported endpoint type
+	UNKNOWN EndpointType = "unknown"
+)
+
+// String returns the string representation of the EndpointType
+func (et EndpointType) String() string {
+	return string(et)
+}
+
+// IsValid checks if the EndpointType is supported
+func (et EndpointType) IsValid() bool {
+	return et == HTTP || et == TCP || et == DNS
+}
+
+// ParseEndpointType parses a string into an EndpointType, an empty string means HTTP
+func ParseEndpointType(s string) EndpointType {
+	switch strings.ToLower(s) {
+	case "", "http", "https":
+		return HTTP
+	case "tcp":
+		return TCP
+	case "dns":
+		return DNS
+	default:
+		return UNKNOWN
+	}
+}
@@ -0,0 +1,38 @@
+package http_method
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ParseHTTPMethod converts a configured method into an HTTP method constant, an empty method means GET
+func ParseHTTPMethod(method string) (string, error) {
+	switch strings.ToUpper(strings.TrimSpace(method)) {
+	case "", http.MethodGet:
+		return http.MethodGet, nil
+	case http.MethodHead:
+		return http.MethodHead, nil
+	case http.MethodPost:
+		return http.MethodPost, nil
+	case http.MethodPut:
+		return http.MethodPut, nil
+	case http.MethodPatch:
+		return http.MethodPatch, nil
+	case http.MethodDelete:
+		return http.MethodDelete, nil
+	case http.MethodConnect:
+		return http.MethodConnect, nil
+	case http.MethodOptions:
+		return http.MethodOptions, nil
+	case http.MethodTrace:
+		return http.MethodTrace, nil
+	default:
+		return "", fmt.Errorf("unsupported HTTP method %q", method)
+	}
+}
+
+// HasResponseBody checks if responses to the method carry a body that can be matched
+func HasResponseBody(method string) bool {
+	return method != http.MethodHead
+}
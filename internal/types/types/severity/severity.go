@@ -0,0 +1,66 @@
+package severity
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Severity string
+
+const (
+	// CRITICAL is for outages that need immediate action, the default severity of services that are down
+	CRITICAL Severity = "critical"
+
+	// ERROR is for problems that need action soon, the default severity of impacted services and expired certificates
+	ERROR Severity = "error"
+
+	// WARNING is for problems that may need action, the default severity of degraded services and expiring certificates
+	WARNING Severity = "warning"
+
+	// INFO is for notices that need no action
+	INFO Severity = "info"
+)
+
+// String returns the string representation of the Severity
+func (s Severity) String() string {
+	return string(s)
+}
+
+// Rank orders the severities from INFO to CRITICAL, unknown severities rank below INFO
+func (s Severity) Rank() int {
+	switch s {
+	case CRITICAL:
+		return 4
+	case ERROR:
+		return 3
+	case WARNING:
+		return 2
+	case INFO:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// AtLeast checks if the severity is at least as severe as the other one
+func (s Severity) AtLeast(other Severity) bool {
+	return s.Rank() >= other.Rank()
+}
+
+// ParseSeverity parses a configured severity, an empty string means that no severity is set
+func ParseSeverity(s string) (Severity, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "":
+		return "", nil
+	case "critical":
+		return CRITICAL, nil
+	case "error":
+		return ERROR, nil
+	case "warning", "warn":
+		return WARNING, nil
+	case "info":
+		return INFO, nil
+	default:
+		return "", fmt.Errorf("unsupported severity %q", s)
+	}
+}
@@ -0,0 +1,48 @@
+package smtp_auth
+
+import (
+	"fmt"
+	"strings"
+)
+
+type SMTPAuth string
+
+const (
+	// NONE sends emails without authentication, for internal relays
+	NONE SMTPAuth = "none"
+
+	// PLAIN authenticates with the PLAIN mechanism
+	PLAIN SMTPAuth = "plain"
+
+	// LOGIN authenticates with the LOGIN mechanism, used by older Exchange and Office 365 servers
+	LOGIN SMTPAuth = "login"
+
+	// CRAMMD5 authenticates with the CRAM-MD5 challenge-response mechanism, the password is never sent
+	CRAMMD5 SMTPAuth = "cram-md5"
+
+	// XOAUTH2 authenticates with an OAuth 2.0 access token, used by Gmail and Office 365
+	XOAUTH2 SMTPAuth = "xoauth2"
+)
+
+// String returns the string representation of the SMTPAuth
+func (a SMTPAuth) String() string {
+	return string(a)
+}
+
+// ParseSMTPAuth parses a configured auth mechanism, an empty string means PLAIN
+func ParseSMTPAuth(s string) (SMTPAuth, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "plain":
+		return PLAIN, nil
+	case "none":
+		return NONE, nil
+	case "login":
+		return LOGIN, nil
+	case "cram-md5", "crammd5":
+		return CRAMMD5, nil
+	case "xoauth2", "oauth2":
+		return XOAUTH2, nil
+	default:
+		return "", fmt.Errorf("unsupported SMTP auth %q", s)
+	}
+}